@@ -21,8 +21,8 @@ func (a *Address) Regions(ctx context.Context, organizationIDs []string) (*BaseR
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := a.client.post(ctx, "/api/1/regions", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/regions", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseRegionsModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -37,8 +37,8 @@ func (a *Address) Cities(ctx context.Context, organizationIDs []string) (*BaseCi
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := a.client.post(ctx, "/api/1/cities", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/cities", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseCitiesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -53,8 +53,8 @@ func (a *Address) StreetsByCity(ctx context.Context, organizationID, cityID stri
 	}
 	body, status, err := a.client.post(ctx, "/api/1/streets/by_city", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/streets/by_city", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseStreetByCityModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -5,6 +5,7 @@ import (
 	"context"
 	"encoding/json"
 	"errors"
+	"fmt"
 	"io"
 	"net/http"
 	"sync"
@@ -25,7 +26,19 @@ type Client struct {
 	clientSecret string
 	token        string
 	tokenAt      time.Time
+	tokenExp     time.Time
+	tokenStore   TokenStore
+	refreshMu    sync.Mutex
+	refreshing   *refreshFlight
+	lazyToken    bool
+	tokenTTL     time.Duration
+	onRefresh    func(token string, expiresAt time.Time)
+	logger       Logger
+	middlewares  []Middleware
+	handler      Handler
 	lastDataRaw  []byte
+	retry        *RetryPolicy
+	limiter      *RateLimiter
 
 	organizationsIDs []string
 
@@ -41,6 +54,12 @@ type Client struct {
 	Commands      *Commands
 	WebHook       *WebHook
 	Employees     *Employees
+	Reserves      *Reserves
+	StopLists     *StopLists
+	Loyalty       *Loyalty
+	Combos        *Combos
+
+	DeliveryRestrictions *DeliveryRestrictions
 }
 
 // Проверяем, что Client реализует IClient
@@ -49,10 +68,12 @@ var _ IClient = (*Client)(nil)
 const (
 	defaultBaseURL = "https://api-ru.iiko.services"
 	defaultTimeout = 15 * time.Second
-	tokenTTL       = 15 * time.Minute
+	// defaultTokenTTL время жизни токена, если его не удалось определить из ответа
+	defaultTokenTTL = 15 * time.Minute
 )
 
-// NewClient создает новый клиент. Если workingToken задан, не запрашивает новый токен сразу
+// NewClient создает новый клиент. Если workingToken задан или включен WithLazyToken,
+// не запрашивает новый токен сразу
 func NewClient(apiLogin string, opts ...Option) (*Client, error) {
 	c := &Client{
 		apiLogin:  apiLogin,
@@ -66,8 +87,15 @@ func NewClient(apiLogin string, opts ...Option) (*Client, error) {
 	for _, opt := range opts {
 		opt(c)
 	}
+	if c.debug && c.logger == nil {
+		c.logger = NewStdLogger(nil)
+	}
+	if c.token != "" && c.tokenExp.IsZero() {
+		c.tokenExp = c.tokenExpiry(c.token)
+	}
+	c.handler = c.buildHandler()
 	// если токена нет, получить
-	if c.token == "" {
+	if c.token == "" && !c.lazyToken {
 		if err := c.refreshToken(context.Background()); err != nil {
 			return nil, err
 		}
@@ -83,8 +111,13 @@ func NewClient(apiLogin string, opts ...Option) (*Client, error) {
 	c.Customers = &Customers{client: c}
 	c.Notifications = &Notifications{client: c}
 	c.Commands = &Commands{client: c}
-	c.WebHook = &WebHook{}
+	c.WebHook = &WebHook{client: c}
 	c.Employees = &Employees{client: c}
+	c.Reserves = &Reserves{client: c}
+	c.StopLists = &StopLists{client: c}
+	c.Loyalty = &Loyalty{client: c}
+	c.Combos = &Combos{client: c}
+	c.DeliveryRestrictions = &DeliveryRestrictions{client: c}
 
 	return c, nil
 }
@@ -114,113 +147,195 @@ func WithReturnDict(v bool) Option { return func(c *Client) { c.returnDict = v }
 func WithWorkingToken(token string) Option {
 	return func(c *Client) {
 		if token != "" {
-			c.setTokenLocked(token)
+			// срок действия вычисляется в NewClient, когда известны все опции
+			c.setTokenLocked(token, time.Time{})
 		}
 	}
 }
 
-func (c *Client) setTokenLocked(token string) {
+// WithRetryPolicy включает повторы запросов по политике p (nil — без повторов, кроме 401)
+func WithRetryPolicy(p *RetryPolicy) Option {
+	return func(c *Client) { c.retry = p }
+}
+
+func (c *Client) setTokenLocked(token string, expiresAt time.Time) {
 	c.mu.Lock()
 	defer c.mu.Unlock()
 	c.token = token
 	c.tokenAt = time.Now()
+	c.tokenExp = expiresAt
 	c.headers.Set("Authorization", "Bearer "+token)
 }
 
+func (c *Client) currentToken() string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.token
+}
+
 func (c *Client) tokenExpired() bool {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
 	if c.token == "" {
 		return true
 	}
-	return time.Since(c.tokenAt) >= tokenTTL
+	return !time.Now().Before(c.tokenExp)
 }
 
-func (c *Client) refreshToken(ctx context.Context) error {
+// fetchToken запрашивает новый токен у /access_token
+func (c *Client) fetchToken(ctx context.Context) (string, error) {
 	data := map[string]string{"apiLogin": c.apiLogin}
-	var requestUri string
-	if c.appId == "" {
-		requestUri = c.baseURL + "/api/1/access_token"
-	} else {
-		requestUri = c.baseURL + "/api/v2/access_token"
+	endpoint := "/api/1/access_token"
+	if c.appId != "" {
+		endpoint = "/api/v2/access_token"
 		data["appId"] = c.appId
 		data["clientSecret"] = c.clientSecret
 	}
 
 	body, _ := json.Marshal(data)
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, requestUri, bytes.NewReader(body))
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+endpoint, bytes.NewReader(body))
 	if err != nil {
-		return err
+		return "", err
 	}
+	c.mu.RLock()
 	req.Header = cloneHeader(c.headers)
+	c.mu.RUnlock()
+	started := time.Now()
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return err
+		if c.logger != nil {
+			logAttempt(c.logger, c.debug, &Request{Endpoint: endpoint}, body, nil, time.Since(started), err)
+		}
+		return "", err
 	}
 	defer resp.Body.Close()
-	b, _ := io.ReadAll(resp.Body)
+	b, err := io.ReadAll(resp.Body)
+	if c.logger != nil {
+		logAttempt(c.logger, c.debug, &Request{Endpoint: endpoint}, body, &Response{StatusCode: resp.StatusCode, Body: b}, time.Since(started), err)
+	}
+	if err != nil {
+		return "", fmt.Errorf("чтение ответа %s: %w", endpoint, err)
+	}
+	if apiErr, ok := ParseAPIError(endpoint, resp.StatusCode, b); ok {
+		return "", apiErr
+	}
 	var out struct {
-		Token            string `json:"token"`
-		ErrorDescription string `json:"errorDescription"`
+		Token string `json:"token"`
 	}
 	_ = json.Unmarshal(b, &out)
-	if out.ErrorDescription != "" {
-		return errors.New(out.ErrorDescription)
-	}
 	if out.Token == "" {
-		return errors.New("empty token in access_token response")
+		return "", errors.New("empty token in access_token response")
 	}
-	c.setTokenLocked(out.Token)
-	return nil
+	return out.Token, nil
 }
 
-// post выполняет POST с повтором при 401 (обновляет токен и повторяет один раз)
+// post выполняет POST через цепочку middleware (см. WithMiddleware, WithRetryPolicy)
 func (c *Client) post(ctx context.Context, url string, payload any) ([]byte, int, error) {
+	return c.postFor(ctx, url, payloadOrganizationID(payload), payload)
+}
+
+// postFor выполняет POST от имени организации organizationID (учитывается лимитером)
+func (c *Client) postFor(ctx context.Context, url, organizationID string, payload any) ([]byte, int, error) {
+	resp, err := c.handler(ctx, &Request{Endpoint: url, Group: endpointFamily(url), OrganizationID: organizationID, Payload: payload})
+	if err != nil {
+		status := 0
+		if resp != nil {
+			status = resp.StatusCode
+		}
+		return nil, status, err
+	}
+	c.mu.Lock()
+	c.lastDataRaw = resp.Body
+	c.mu.Unlock()
+	captureRawResponse(ctx, url, resp.StatusCode, resp.Header, resp.Body)
+	return resp.Body, resp.StatusCode, nil
+}
+
+// requestData раскладывает модель запроса с json-тегами в map и дополняет ее полями extra
+func requestData(model any, extra map[string]any) (map[string]any, error) {
+	data := map[string]any{}
+	b, err := json.Marshal(model)
+	if err != nil {
+		return nil, err
+	}
+	// nil-модель кодируется как null и оставляет data пустой
+	if err := json.Unmarshal(b, &data); err != nil {
+		return nil, err
+	}
+	if data == nil {
+		data = map[string]any{}
+	}
+	for k, v := range extra {
+		data[k] = v
+	}
+	return data, nil
+}
+
+// query выполняет POST и разбирает успешный ответ в out
+func (c *Client) query(ctx context.Context, url string, payload any, out any) (*CustomErrorModel, error) {
+	return c.queryFor(ctx, url, payloadOrganizationID(payload), payload, out)
+}
+
+// queryFor как query, но организация запроса задается явно, например для тела-структуры
+func (c *Client) queryFor(ctx context.Context, url, organizationID string, payload any, out any) (*CustomErrorModel, error) {
+	body, status, err := c.postFor(ctx, url, organizationID, payload)
+	if err != nil {
+		return nil, err
+	}
+	if cerr := detectCustomError(url, status, body); cerr != nil {
+		return cerr, nil
+	}
+	return nil, json.Unmarshal(body, out)
+}
+
+// transport последнее звено цепочки: сериализация, HTTP-запрос и обновление токена.
+// При 401 токен обновляется и запрос повторяется один раз; если токен уже обновил
+// параллельный запрос, повторный запрос к /access_token не делается.
+func (c *Client) transport(ctx context.Context, req *Request) (*Response, error) {
 	if c.tokenExpired() {
 		if err := c.refreshToken(ctx); err != nil {
-			return nil, 0, err
+			return nil, err
 		}
 	}
-	body, err := json.Marshal(payload)
+	body, err := json.Marshal(req.Payload)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
+	}
+	used := c.currentToken()
+	resp, err := c.do(ctx, req, body)
+	if err != nil || resp.StatusCode != http.StatusUnauthorized {
+		return resp, err
 	}
-	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+url, bytes.NewReader(body))
+	if c.currentToken() == used {
+		if err := c.refreshToken(ctx); err != nil {
+			return resp, err
+		}
+	}
+	return c.do(ctx, req, body)
+}
+
+func (c *Client) do(ctx context.Context, r *Request, body []byte) (*Response, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+r.Endpoint, bytes.NewReader(body))
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
+	c.mu.RLock()
 	req.Header = cloneHeader(c.headers)
+	c.mu.RUnlock()
+	for k, v := range r.Header {
+		req.Header[k] = append([]string(nil), v...)
+	}
 	resp, err := c.client.Do(req)
 	if err != nil {
-		return nil, 0, err
+		return nil, err
 	}
 	defer resp.Body.Close()
-	b, _ := io.ReadAll(resp.Body)
-	// 401 -> обновить токен и один повтор
-	if resp.StatusCode == http.StatusUnauthorized {
-		if err := c.refreshToken(ctx); err != nil {
-			return nil, resp.StatusCode, err
-		}
-		req2, err := http.NewRequestWithContext(ctx, http.MethodPost, c.baseURL+url, bytes.NewReader(body))
-		if err != nil {
-			return nil, 0, err
-		}
-		req2.Header = cloneHeader(c.headers)
-		resp2, err := c.client.Do(req2)
-		if err != nil {
-			return nil, 0, err
-		}
-		defer resp2.Body.Close()
-		b2, _ := io.ReadAll(resp2.Body)
-		c.mu.Lock()
-		c.lastDataRaw = b2
-		c.mu.Unlock()
-		return b2, resp2.StatusCode, nil
+	b, err := io.ReadAll(resp.Body)
+	out := &Response{StatusCode: resp.StatusCode, Header: resp.Header, Body: b}
+	if err != nil {
+		return out, fmt.Errorf("чтение ответа %s: %w", r.Endpoint, err)
 	}
-	c.mu.Lock()
-	c.lastDataRaw = b
-	c.mu.Unlock()
-	return b, resp.StatusCode, nil
+	return out, nil
 }
 
 func cloneHeader(h http.Header) http.Header {
@@ -250,8 +365,7 @@ func (c *Client) Organizations(ctx context.Context, organizationIDs []string, re
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		cerr := &CustomErrorModel{ErrorModel: ErrorModel{BaseResponseModel: BaseResponseModel{}, ErrorDescription: msg}, StatusCode: status}
+	if cerr := detectCustomError("/api/1/organizations", status, body); cerr != nil {
 		return nil, cerr, nil
 	}
 	var out BaseOrganizationsModel
@@ -265,7 +379,16 @@ func (c *Client) Organizations(ctx context.Context, organizationIDs []string, re
 	return &out, nil, nil
 }
 
-// LastDataRaw возвращает сырое тело последнего ответа
+// organizationIDs id организаций, сохраненные последним вызовом Organizations
+func (c *Client) organizationIDs() []string {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return append([]string(nil), c.organizationsIDs...)
+}
+
+// LastDataRaw возвращает сырое тело последнего ответа.
+//
+// Deprecated: значение общее для всех горутин клиента; используйте WithRawResponse.
 func (c *Client) LastDataRaw() []byte {
 	c.mu.RLock()
 	defer c.mu.RUnlock()
@@ -284,3 +407,10 @@ func (c *Client) GetNotifications() INotifications { return c.Notifications }
 func (c *Client) GetCommands() ICommands           { return c.Commands }
 func (c *Client) GetWebHook() IWebHook             { return c.WebHook }
 func (c *Client) GetEmployees() IEmployees         { return c.Employees }
+func (c *Client) GetReserves() IReserves           { return c.Reserves }
+func (c *Client) GetStopLists() IStopLists         { return c.StopLists }
+func (c *Client) GetLoyalty() ILoyalty             { return c.Loyalty }
+func (c *Client) GetCombos() ICombos               { return c.Combos }
+func (c *Client) GetDeliveryRestrictions() IDeliveryRestrictions {
+	return c.DeliveryRestrictions
+}
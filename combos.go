@@ -0,0 +1,138 @@
+package goiikoapi
+
+import (
+	"context"
+	"crypto/rand"
+	"fmt"
+)
+
+// Combos содержит методы для работы с комбо
+type Combos struct {
+	client *Client
+}
+
+// Проверяем, что Combos реализует ICombos
+var _ ICombos = (*Combos)(nil)
+
+// Combos реплицирует Combos.combo: спецификации и категории комбо организации
+func (c *Combos) Combos(ctx context.Context, organizationID string, extraData bool) (*BaseCombosModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"extraData":      extraData,
+	}
+	var out BaseCombosModel
+	if cerr, err := c.client.query(ctx, "/api/1/combo", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// ComboPrice реплицирует Combos.get_combo_price: цена комбо из выбранных продуктов групп
+func (c *Combos) ComboPrice(ctx context.Context, organizationID string, items []ComboPriceItemRequestModel) (*ComboPriceModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"items":          items,
+	}
+	var out ComboPriceModel
+	if cerr, err := c.client.query(ctx, "/api/1/combo/get_combo_price", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// ComboChoice продукт, выбранный гостем в группе комбо
+type ComboChoice struct {
+	GroupID   string
+	ProductID string
+	SizeID    *string
+	Opts      []OrderItemOption
+}
+
+// PriceItems позиции для ComboPrice по выбору гостя
+func (s *ComboSpecificationModel) PriceItems(choices []ComboChoice) []ComboPriceItemRequestModel {
+	items := make([]ComboPriceItemRequestModel, 0, len(choices))
+	for _, ch := range choices {
+		items = append(items, ComboPriceItemRequestModel{ProductID: ch.ProductID, SizeID: ch.SizeID, GroupID: ch.GroupID})
+	}
+	return items
+}
+
+// Expand раскладывает комбо в заказ: комбо и связанные с ним позиции (comboId, comboSourceId, comboGroupId).
+// Пустой comboID заменяется новым UUID; price — цена комбо из ComboPrice.
+// Проверяет, что продукты входят в свои группы, группа выбрана не больше одного раза и основная группа заполнена.
+//
+//	combo, items, err := spec.Expand("", price.Price, 1, choices)
+//	b.AddCombo(combo)
+//	for _, it := range items { b.AddItem(it) }
+func (s *ComboSpecificationModel) Expand(comboID string, price float64, amount int, choices []ComboChoice) (OrderComboRequestModel, []OrderItemRequestModel, error) {
+	if amount <= 0 {
+		amount = 1
+	}
+	if comboID == "" {
+		comboID = newUUID()
+	}
+	groups := make(map[string]*ComboGroupModel, len(s.Groups))
+	for i := range s.Groups {
+		groups[s.Groups[i].ID] = &s.Groups[i]
+	}
+	chosen := map[string]bool{}
+	items := make([]OrderItemRequestModel, 0, len(choices))
+	for _, ch := range choices {
+		g, ok := groups[ch.GroupID]
+		if !ok {
+			return OrderComboRequestModel{}, nil, fmt.Errorf("комбо %s: группа %s не найдена", s.Name, ch.GroupID)
+		}
+		if chosen[ch.GroupID] {
+			return OrderComboRequestModel{}, nil, fmt.Errorf("комбо %s: группа %s выбрана дважды", s.Name, g.Name)
+		}
+		if !g.hasProduct(ch.ProductID, ch.SizeID) {
+			return OrderComboRequestModel{}, nil, fmt.Errorf("комбо %s: продукт %s не входит в группу %s", s.Name, ch.ProductID, g.Name)
+		}
+		chosen[ch.GroupID] = true
+		item := OrderItemRequestModel{
+			Type:          OrderItemTypeProduct,
+			ProductID:     ch.ProductID,
+			Amount:        float64(amount),
+			ProductSizeID: ch.SizeID,
+		}
+		for _, opt := range ch.Opts {
+			opt(&item)
+		}
+		WithItemCombo(comboID, s.SourceActionID, ch.GroupID)(&item)
+		items = append(items, item)
+	}
+	for _, g := range s.Groups {
+		if g.IsMainGroup && !chosen[g.ID] {
+			return OrderComboRequestModel{}, nil, fmt.Errorf("комбо %s: не выбран продукт основной группы %s", s.Name, g.Name)
+		}
+	}
+	combo := OrderComboRequestModel{
+		ID:       comboID,
+		Name:     s.Name,
+		Amount:   amount,
+		Price:    price,
+		SourceID: s.SourceActionID,
+	}
+	return combo, items, nil
+}
+
+func (g *ComboGroupModel) hasProduct(productID string, sizeID *string) bool {
+	for _, p := range g.Products {
+		if p.ProductID != productID {
+			continue
+		}
+		if p.SizeID == nil || (sizeID != nil && *p.SizeID == *sizeID) {
+			return true
+		}
+	}
+	return false
+}
+
+// newUUID случайный UUID версии 4
+func newUUID() string {
+	var b [16]byte
+	_, _ = rand.Read(b[:])
+	b[6] = b[6]&0x0f | 0x40
+	b[8] = b[8]&0x3f | 0x80
+	return fmt.Sprintf("%x-%x-%x-%x-%x", b[0:4], b[4:6], b[6:8], b[8:10], b[10:16])
+}
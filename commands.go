@@ -3,6 +3,10 @@ package goiikoapi
 import (
 	"context"
 	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"time"
 )
 
 // Commands содержит методы для работы с командами
@@ -21,10 +25,190 @@ func (c *Commands) Status(ctx context.Context, organizationID, correlationID str
 	}
 	body, status, err := c.client.post(ctx, "/api/1/commands/status", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/commands/status", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseStatusModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
 	return &out, nil, nil
 }
+
+// Состояния команды из /api/1/commands/status
+const (
+	CommandStateInProgress = "InProgress"
+	CommandStateSuccess    = "Success"
+	CommandStateError      = "Error"
+)
+
+// ErrCommandTimeout команда не завершилась за отведенное время
+var ErrCommandTimeout = errors.New("команда iiko не завершилась за отведенное время")
+
+// WaitOptions параметры ожидания команды. Нулевые значения заменяются значениями по умолчанию
+type WaitOptions struct {
+	// Timeout общий дедлайн ожидания (по умолчанию 30s)
+	Timeout time.Duration
+	// InitialDelay пауза перед повторным запросом статуса (по умолчанию 300ms)
+	InitialDelay time.Duration
+	// MaxDelay верхняя граница паузы (по умолчанию 5s)
+	MaxDelay time.Duration
+	// Multiplier множитель экспоненциальной паузы (по умолчанию 2)
+	Multiplier float64
+}
+
+func (o *WaitOptions) withDefaults() WaitOptions {
+	out := WaitOptions{}
+	if o != nil {
+		out = *o
+	}
+	if out.Timeout <= 0 {
+		out.Timeout = 30 * time.Second
+	}
+	if out.InitialDelay <= 0 {
+		out.InitialDelay = 300 * time.Millisecond
+	}
+	if out.MaxDelay <= 0 {
+		out.MaxDelay = 5 * time.Second
+	}
+	if out.Multiplier < 1 {
+		out.Multiplier = 2
+	}
+	return out
+}
+
+// CommandResult итог ожидания команды
+type CommandResult struct {
+	CorrelationID string
+	// State итоговое состояние: Success, Error или InProgress (если истек Timeout)
+	State    string
+	Status   *BaseStatusModel
+	Message  string
+	TimedOut bool
+	Attempts int
+}
+
+// Err возвращает ошибку для состояний Error и InProgress-timeout, иначе nil
+func (r *CommandResult) Err() error {
+	switch {
+	case r.TimedOut:
+		return fmt.Errorf("%w: correlationId %s", ErrCommandTimeout, r.CorrelationID)
+	case r.State == CommandStateError:
+		if r.Message == "" {
+			return fmt.Errorf("команда iiko %s завершилась с ошибкой", r.CorrelationID)
+		}
+		return fmt.Errorf("команда iiko %s завершилась с ошибкой: %s", r.CorrelationID, r.Message)
+	}
+	return nil
+}
+
+// WaitForCommand опрашивает Commands.Status с экспоненциальной паузой до завершения команды
+// или истечения opts.Timeout. Истечение Timeout не считается ошибкой: результат вернется с TimedOut.
+func (c *Commands) WaitForCommand(ctx context.Context, organizationID, correlationID string, opts *WaitOptions) (*CommandResult, *CustomErrorModel, error) {
+	o := opts.withDefaults()
+	waitCtx, cancel := context.WithTimeout(ctx, o.Timeout)
+	defer cancel()
+
+	res := &CommandResult{CorrelationID: correlationID, State: CommandStateInProgress}
+	delay := o.InitialDelay
+	for {
+		res.Attempts++
+		st, cerr, err := c.Status(waitCtx, organizationID, correlationID)
+		if err != nil {
+			if ctx.Err() == nil && waitCtx.Err() != nil {
+				res.TimedOut = true
+				return res, nil, nil
+			}
+			return nil, nil, err
+		}
+		if cerr != nil {
+			return nil, cerr, nil
+		}
+		res.Status = st
+		res.State = st.State
+		if st.State != CommandStateInProgress {
+			if st.Exception != nil && st.Exception.Message != nil {
+				res.Message = *st.Exception.Message
+			}
+			return res, nil, nil
+		}
+
+		t := time.NewTimer(delay)
+		select {
+		case <-waitCtx.Done():
+			t.Stop()
+			if ctx.Err() != nil {
+				return nil, nil, ctx.Err()
+			}
+			res.TimedOut = true
+			return res, nil, nil
+		case <-t.C:
+		}
+		delay = time.Duration(float64(delay) * o.Multiplier)
+		if delay > o.MaxDelay {
+			delay = o.MaxDelay
+		}
+	}
+}
+
+type commandWaitKey struct{}
+
+// WithCommandWait включает синхронный режим для мутирующих методов: вызов с таким контекстом
+// дождется завершения команды через WaitForCommand и вернет ошибку iiko, если команда упала.
+// Данные ответа (correlationId, созданный заказ) возвращаются и вместе с ошибкой ожидания,
+// чтобы по ним можно было сверить состояние вместо повторного создания.
+func WithCommandWait(ctx context.Context, opts *WaitOptions) context.Context {
+	if opts == nil {
+		opts = &WaitOptions{}
+	}
+	return context.WithValue(ctx, commandWaitKey{}, opts)
+}
+
+// awaitCommand ждет команду, если контекст создан через WithCommandWait
+func (c *Client) awaitCommand(ctx context.Context, organizationID, correlationID string) (*CustomErrorModel, error) {
+	opts, ok := ctx.Value(commandWaitKey{}).(*WaitOptions)
+	if !ok || correlationID == "" {
+		return nil, nil
+	}
+	cmd := &Commands{client: c}
+	res, cerr, err := cmd.WaitForCommand(ctx, organizationID, correlationID, opts)
+	if err != nil || cerr != nil {
+		return cerr, err
+	}
+	if res.TimedOut {
+		return nil, res.Err()
+	}
+	if res.State == CommandStateError {
+		return &CustomErrorModel{
+			ErrorModel: ErrorModel{BaseResponseModel: BaseResponseModel{CorrelationID: correlationID}, ErrorDescription: res.Message},
+			StatusCode: http.StatusOK,
+			Endpoint:   "/api/1/commands/status",
+		}, nil
+	}
+	return nil, nil
+}
+
+func firstID(ids []string) string {
+	if len(ids) == 0 {
+		return ""
+	}
+	return ids[0]
+}
+
+// command выполняет метод-команду iiko, возвращающий только correlationId,
+// с учетом синхронного режима WithCommandWait
+func (c *Client) command(ctx context.Context, url, organizationID string, data map[string]any) (*BaseResponseModel, *CustomErrorModel, error) {
+	body, status, err := c.postFor(ctx, url, organizationID, data)
+	if err != nil {
+		return nil, nil, err
+	}
+	if cerr := detectCustomError(url, status, body); cerr != nil {
+		return nil, cerr, nil
+	}
+	var out BaseResponseModel
+	if err := json.Unmarshal(body, &out); err != nil {
+		return nil, nil, err
+	}
+	if cerr, err := c.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil {
+		return &out, cerr, err
+	}
+	return &out, nil, nil
+}
@@ -0,0 +1,147 @@
+package goiikoapi
+
+import (
+	"context"
+	"sort"
+	"time"
+)
+
+// CourierOrder заказ доставки, назначенный курьеру
+type CourierOrder struct {
+	Order ByOrderItemModel
+	// IndexInRoute порядковый номер заказа в маршруте курьера (IndexInCourierRoute), -1 если не задан
+	IndexInRoute int
+}
+
+// CourierPosition положение курьера с назначенными ему заказами в порядке маршрута
+type CourierPosition struct {
+	OrganizationID string
+	CourierID      string
+	Location       CourierLocationPointModel
+	Orders         []CourierOrder
+}
+
+// CourierTrackerConfig настройки CourierTracker
+type CourierTrackerConfig struct {
+	OrganizationIDs []string
+	// Interval период опроса, по умолчанию 30 секунд
+	Interval time.Duration
+	// Statuses статусы заказов, которые считаются назначенными курьеру; по умолчанию Waiting и OnWay
+	Statuses []string
+	// Lookback глубина поиска заказов по дате доставки, по умолчанию 24 часа
+	Lookback time.Duration
+	// OnError вызывается при ошибке опроса; опрос продолжается
+	OnError func(error)
+}
+
+// CourierTracker периодически опрашивает координаты курьеров и сообщает об их перемещениях
+// вместе с назначенными заказами доставки (CourierInfoModel, IndexInCourierRoute)
+type CourierTracker struct {
+	employees  IEmployees
+	deliveries IDeliveries
+	cfg        CourierTrackerConfig
+	last       map[string]CourierLocationPointModel
+}
+
+// NewCourierTracker создает трекер курьеров
+func NewCourierTracker(employees IEmployees, deliveries IDeliveries, cfg CourierTrackerConfig) *CourierTracker {
+	if cfg.Interval <= 0 {
+		cfg.Interval = 30 * time.Second
+	}
+	if len(cfg.Statuses) == 0 {
+		cfg.Statuses = []string{"Waiting", "OnWay"}
+	}
+	if cfg.Lookback <= 0 {
+		cfg.Lookback = 24 * time.Hour
+	}
+	return &CourierTracker{
+		employees:  employees,
+		deliveries: deliveries,
+		cfg:        cfg,
+		last:       map[string]CourierLocationPointModel{},
+	}
+}
+
+// Poll выполняет один опрос и возвращает положения всех активных курьеров
+func (t *CourierTracker) Poll(ctx context.Context) ([]CourierPosition, *CustomErrorModel, error) {
+	locations, cerr, err := t.employees.CouriersActiveLocation(ctx, t.cfg.OrganizationIDs, nil)
+	if cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	from := time.Now().Add(-t.cfg.Lookback).Format(TimeLayout)
+	orders, cerr, err := t.deliveries.ByDeliveryDateAndStatus(ctx, t.cfg.OrganizationIDs, from, "", t.cfg.Statuses, nil)
+	if cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	byCourier := map[string][]CourierOrder{}
+	for _, org := range orders.OrdersByOrganizations {
+		for _, o := range org.Orders {
+			if o.Order == nil || o.Order.CourierInfo == nil {
+				continue
+			}
+			index := -1
+			if o.Order.IndexInCourierRoute != nil {
+				index = *o.Order.IndexInCourierRoute
+			}
+			id := o.Order.CourierInfo.Courier.ID
+			byCourier[id] = append(byCourier[id], CourierOrder{Order: o, IndexInRoute: index})
+		}
+	}
+	var out []CourierPosition
+	for _, org := range locations.ActiveCourierLocations {
+		for _, l := range org.Items {
+			routed := byCourier[l.CourierID]
+			sort.SliceStable(routed, func(i, j int) bool {
+				a, b := routed[i].IndexInRoute, routed[j].IndexInRoute
+				if a < 0 || b < 0 {
+					return b < 0 && a >= 0
+				}
+				return a < b
+			})
+			out = append(out, CourierPosition{
+				OrganizationID: org.OrganizationID,
+				CourierID:      l.CourierID,
+				Location:       l.LastActiveLocation,
+				Orders:         routed,
+			})
+		}
+	}
+	return out, nil, nil
+}
+
+// Run опрашивает координаты с интервалом Interval и вызывает onUpdate для курьеров,
+// чье положение изменилось с прошлого опроса. Блокируется до отмены ctx.
+func (t *CourierTracker) Run(ctx context.Context, onUpdate func(CourierPosition)) error {
+	ticker := time.NewTicker(t.cfg.Interval)
+	defer ticker.Stop()
+	for {
+		positions, cerr, err := t.Poll(ctx)
+		if err == nil {
+			err = cerr.Err()
+		}
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			if t.cfg.OnError != nil {
+				t.cfg.OnError(err)
+			}
+		}
+		for _, p := range positions {
+			if prev, ok := t.last[p.CourierID]; ok && sameLocation(prev, p.Location) {
+				continue
+			}
+			t.last[p.CourierID] = p.Location
+			onUpdate(p)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func sameLocation(a, b CourierLocationPointModel) bool {
+	return a.Latitude == b.Latitude && a.Longitude == b.Longitude
+}
@@ -80,8 +80,8 @@ func (c *Customers) CustomerInfo(ctx context.Context, organizationID, identifier
 	}
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/info", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/info", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out CustomerInfoModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -99,8 +99,8 @@ func (c *Customers) CustomerCreateOrUpdate(ctx context.Context, organizationID s
 	
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/create_or_update", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/create_or_update", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out CustomerCreateOrUpdateModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -116,8 +116,8 @@ func (c *Customers) CustomerProgramAdd(ctx context.Context, customerID, programI
 	}
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/program/add", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/program/add", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out CustomerProgramAddResponse
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -134,8 +134,8 @@ func (c *Customers) CustomerCardAdd(ctx context.Context, customerID, cardTrack,
 	}
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/card/add", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/card/add", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -151,8 +151,8 @@ func (c *Customers) CustomerCardDelete(ctx context.Context, customerID, cardTrac
 	}
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/card/remove", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/card/remove", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -172,8 +172,8 @@ func (c *Customers) CustomerWalletHold(ctx context.Context, customerID, walletID
 	
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/wallet/hold", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/wallet/hold", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out WalletHoldResponse
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -188,8 +188,8 @@ func (c *Customers) CustomerWalletCancelHold(ctx context.Context, organizationID
 	}
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/wallet/cancel_hold", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/wallet/cancel_hold", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -208,8 +208,8 @@ func (c *Customers) CustomerWalletTopup(ctx context.Context, customerID, walletI
 	
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/wallet/topup", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/wallet/topup", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -228,10 +228,82 @@ func (c *Customers) CustomerWalletChargeoff(ctx context.Context, customerID, wal
 	
 	body, status, err := c.client.post(ctx, "/api/1/loyalty/iiko/customer/wallet/chargeoff", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/loyalty/iiko/customer/wallet/chargeoff", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
 	return &out, nil, nil
 }
+
+// CustomerCategories реплицирует Customers.customer_category: все категории гостей организации
+func (c *Customers) CustomerCategories(ctx context.Context, organizationID string) (*CustomerCategoriesModel, *CustomErrorModel, error) {
+	data := map[string]any{"organizationId": organizationID}
+	var out CustomerCategoriesModel
+	if cerr, err := c.client.query(ctx, "/api/1/loyalty/iiko/customer_category", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CustomerCategoryAdd реплицирует Customers.customer_category_add
+func (c *Customers) CustomerCategoryAdd(ctx context.Context, customerID, categoryID, organizationID string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"customerId": customerID,
+		"categoryId": categoryID,
+		"organizationId": organizationID,
+	}
+	var out BaseResponseModel
+	if cerr, err := c.client.query(ctx, "/api/1/loyalty/iiko/customer_category/add", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CustomerCategoryRemove реплицирует Customers.customer_category_remove
+func (c *Customers) CustomerCategoryRemove(ctx context.Context, customerID, categoryID, organizationID string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"customerId": customerID,
+		"categoryId": categoryID,
+		"organizationId": organizationID,
+	}
+	var out BaseResponseModel
+	if cerr, err := c.client.query(ctx, "/api/1/loyalty/iiko/customer_category/remove", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CustomerTransactionsByDate реплицирует Customers.transactions_by_date.
+// pageNumber начинается с 0; для обхода всех страниц см. NewTransactionsByDateIterator.
+func (c *Customers) CustomerTransactionsByDate(ctx context.Context, customerID, organizationID, dateFrom, dateTo string, pageNumber, pageSize int) (*CustomerTransactionsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"customerId": customerID,
+		"organizationId": organizationID,
+		"dateFrom": dateFrom,
+		"dateTo": dateTo,
+		"pageNumber": pageNumber,
+		"pageSize": pageSize,
+	}
+	var out CustomerTransactionsModel
+	if cerr, err := c.client.query(ctx, "/api/1/loyalty/iiko/customer/transactions/by_date", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CustomerTransactionsByRevision реплицирует Customers.transactions_by_revision.
+// Возвращает транзакции после revision; LastRevision ответа — revision следующего вызова.
+func (c *Customers) CustomerTransactionsByRevision(ctx context.Context, customerID, organizationID string, revision int64, pageSize int) (*CustomerTransactionsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"customerId": customerID,
+		"organizationId": organizationID,
+		"revision": revision,
+		"pageSize": pageSize,
+	}
+	var out CustomerTransactionsModel
+	if cerr, err := c.client.query(ctx, "/api/1/loyalty/iiko/customer/transactions/by_revision", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
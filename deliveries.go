@@ -3,6 +3,7 @@ package goiikoapi
 import (
 	"context"
 	"encoding/json"
+	"time"
 )
 
 // Deliveries содержит методы для работы с доставкой
@@ -15,6 +16,15 @@ var _ IDeliveries = (*Deliveries)(nil)
 
 // DeliveryCreate реплицирует Deliveries.delivery_create
 func (d *Deliveries) DeliveryCreate(ctx context.Context, organizationID string, order map[string]any, terminalGroupID *string, createOrderSettings *int) (*BaseCreatedDeliveryOrderInfoModel, *CustomErrorModel, error) {
+	return d.deliveryCreate(ctx, organizationID, order, terminalGroupID, createOrderSettings)
+}
+
+// DeliveryCreateTyped создает заказ доставки из типизированной модели (см. NewOrderBuilder)
+func (d *Deliveries) DeliveryCreateTyped(ctx context.Context, organizationID string, order *OrderRequestModel, terminalGroupID *string, createOrderSettings *int) (*BaseCreatedDeliveryOrderInfoModel, *CustomErrorModel, error) {
+	return d.deliveryCreate(ctx, organizationID, order, terminalGroupID, createOrderSettings)
+}
+
+func (d *Deliveries) deliveryCreate(ctx context.Context, organizationID string, order any, terminalGroupID *string, createOrderSettings *int) (*BaseCreatedDeliveryOrderInfoModel, *CustomErrorModel, error) {
 	data := map[string]any{
 		"organizationId": organizationID,
 		"order": order,
@@ -27,11 +37,12 @@ func (d *Deliveries) DeliveryCreate(ctx context.Context, organizationID string,
 	}
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/create", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/create", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseCreatedDeliveryOrderInfoModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := d.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
 
@@ -47,11 +58,12 @@ func (d *Deliveries) UpdateOrderDeliveryStatus(ctx context.Context, organization
 	}
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/update_order_delivery_status", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/update_order_delivery_status", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := d.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
 
@@ -63,11 +75,12 @@ func (d *Deliveries) Confirm(ctx context.Context, organizationID string, orderID
 	}
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/confirm", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/confirm", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := d.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
 
@@ -79,11 +92,12 @@ func (d *Deliveries) CancelConfirmation(ctx context.Context, organizationIDs []s
 	}
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/cancel_confirmation", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/cancel_confirmation", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := d.client.awaitCommand(ctx, firstID(organizationIDs), out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
 
@@ -99,8 +113,47 @@ func (d *Deliveries) ByDeliveryDateAndStatus(ctx context.Context, organizationID
 	
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/by_delivery_date_and_status", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/by_delivery_date_and_status", status, body); cerr != nil {
+		return nil, cerr, nil
+	}
+	var out ByDeliveryDateAndStatusModel
+	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	return &out, nil, nil
+}
+
+// ByDeliveryDateAndPhone реплицирует Deliveries.by_delivery_date_and_phone
+func (d *Deliveries) ByDeliveryDateAndPhone(ctx context.Context, organizationIDs []string, phone, deliveryDateFrom, deliveryDateTo string, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"phone": phone,
+		"deliveryDateFrom": deliveryDateFrom,
+	}
+	if deliveryDateTo != "" { data["deliveryDateTo"] = deliveryDateTo }
+	if len(sourceKeys) > 0 { data["sourceKeys"] = sourceKeys }
+
+	body, status, err := d.client.post(ctx, "/api/1/deliveries/by_delivery_date_and_phone", data)
+	if err != nil { return nil, nil, err }
+	if cerr := detectCustomError("/api/1/deliveries/by_delivery_date_and_phone", status, body); cerr != nil {
+		return nil, cerr, nil
+	}
+	var out ByDeliveryDateAndStatusModel
+	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	return &out, nil, nil
+}
+
+// ByRevision реплицирует Deliveries.by_revision.
+// Возвращает заказы, измененные после startRevision; MaxRevision ответа — стартовая ревизия следующего вызова.
+func (d *Deliveries) ByRevision(ctx context.Context, organizationIDs []string, startRevision int64, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"startRevision": startRevision,
+	}
+	if len(sourceKeys) > 0 { data["sourceKeys"] = sourceKeys }
+
+	body, status, err := d.client.post(ctx, "/api/1/deliveries/by_revision", data)
+	if err != nil { return nil, nil, err }
+	if cerr := detectCustomError("/api/1/deliveries/by_revision", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out ByDeliveryDateAndStatusModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -157,10 +210,126 @@ func (d *Deliveries) ByDeliveryDateAndSourceKeyAndFilter(ctx context.Context, or
 	
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/by_delivery_date_and_source_key_and_filter", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/by_delivery_date_and_source_key_and_filter", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out ByDeliveryDateAndSourceKeyAndFilter
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
 	return &out, nil, nil
 }
+
+// AddItems реплицирует Deliveries.add_items
+func (d *Deliveries) AddItems(ctx context.Context, organizationID, orderID string, items []OrderItemRequestModel, combos []OrderComboRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"items": items,
+	}
+	if len(combos) > 0 { data["combos"] = combos }
+	return d.client.command(ctx, "/api/1/deliveries/add_items", organizationID, data)
+}
+
+// Close реплицирует Deliveries.close
+func (d *Deliveries) Close(ctx context.Context, organizationID, orderID string, deliveryDate *string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+	}
+	if deliveryDate != nil { data["deliveryDate"] = *deliveryDate }
+	return d.client.command(ctx, "/api/1/deliveries/close", organizationID, data)
+}
+
+// Cancel реплицирует Deliveries.cancel
+func (d *Deliveries) Cancel(ctx context.Context, organizationID, orderID string, cancel *DeliveryCancelRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data, err := requestData(cancel, map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+	})
+	if err != nil { return nil, nil, err }
+	return d.client.command(ctx, "/api/1/deliveries/cancel", organizationID, data)
+}
+
+// ChangeCompleteBefore реплицирует Deliveries.change_complete_before
+func (d *Deliveries) ChangeCompleteBefore(ctx context.Context, organizationID, orderID string, completeBefore time.Time) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"newCompleteBefore": completeBefore.Format(TimeLayout),
+	}
+	return d.client.command(ctx, "/api/1/deliveries/change_complete_before", organizationID, data)
+}
+
+// ChangeDeliveryPoint реплицирует Deliveries.change_delivery_point
+func (d *Deliveries) ChangeDeliveryPoint(ctx context.Context, organizationID, orderID string, deliveryPoint *DeliveryPointRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"newDeliveryPoint": deliveryPoint,
+	}
+	return d.client.command(ctx, "/api/1/deliveries/change_delivery_point", organizationID, data)
+}
+
+// ChangeServiceType реплицирует Deliveries.change_service_type.
+// deliveryPoint обязателен при смене на OrderServiceTypeDeliveryByCourier.
+func (d *Deliveries) ChangeServiceType(ctx context.Context, organizationID, orderID, serviceType string, deliveryPoint *DeliveryPointRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"newServiceType": serviceType,
+	}
+	if deliveryPoint != nil { data["deliveryPoint"] = deliveryPoint }
+	return d.client.command(ctx, "/api/1/deliveries/change_service_type", organizationID, data)
+}
+
+// ChangePayments реплицирует Deliveries.change_payments
+func (d *Deliveries) ChangePayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"payments": payments,
+	}
+	if len(tips) > 0 { data["tips"] = tips }
+	return d.client.command(ctx, "/api/1/deliveries/change_payments", organizationID, data)
+}
+
+// ChangeComment реплицирует Deliveries.change_comment
+func (d *Deliveries) ChangeComment(ctx context.Context, organizationID, orderID, comment string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"comment": comment,
+	}
+	return d.client.command(ctx, "/api/1/deliveries/change_comment", organizationID, data)
+}
+
+// ChangeDriverInfo реплицирует Deliveries.change_driver_info (назначение курьера)
+func (d *Deliveries) ChangeDriverInfo(ctx context.Context, organizationID, orderID, driverID string, estimatedTime *time.Time) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"driverId": driverID,
+	}
+	if estimatedTime != nil { data["estimatedTime"] = estimatedTime.Format(TimeLayout) }
+	return d.client.command(ctx, "/api/1/deliveries/change_driver_info", organizationID, data)
+}
+
+// UpdateOrderProblem реплицирует Deliveries.update_order_problem.
+// hasProblem == false снимает проблему с заказа.
+func (d *Deliveries) UpdateOrderProblem(ctx context.Context, organizationID, orderID string, hasProblem bool, problem *string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"hasProblem": hasProblem,
+	}
+	if problem != nil { data["problem"] = *problem }
+	return d.client.command(ctx, "/api/1/deliveries/update_order_problem", organizationID, data)
+}
+
+// PrintDeliveryBill реплицирует Deliveries.print_delivery_bill
+func (d *Deliveries) PrintDeliveryBill(ctx context.Context, organizationID, orderID string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+	}
+	return d.client.command(ctx, "/api/1/deliveries/print_delivery_bill", organizationID, data)
+}
@@ -0,0 +1,136 @@
+package goiikoapi
+
+import (
+	"context"
+	"errors"
+	"math"
+)
+
+// DeliveryRestrictions содержит методы для работы с ограничениями и зонами доставки
+type DeliveryRestrictions struct {
+	client *Client
+}
+
+// Проверяем, что DeliveryRestrictions реализует IDeliveryRestrictions
+var _ IDeliveryRestrictions = (*DeliveryRestrictions)(nil)
+
+// Restrictions реплицирует DeliveryRestrictions.delivery_restrictions: зоны, минимальные суммы и время доставки
+func (d *DeliveryRestrictions) Restrictions(ctx context.Context, organizationIDs []string) (*BaseDeliveryRestrictionsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+	}
+	var out BaseDeliveryRestrictionsModel
+	if cerr, err := d.client.query(ctx, "/api/1/delivery_restrictions", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Update реплицирует DeliveryRestrictions.update. Передается полная модель ограничений организации,
+// обычно полученная из Restrictions и измененная.
+func (d *DeliveryRestrictions) Update(ctx context.Context, restrictions *DeliveryRestrictionModel) (*BaseDeliveryRestrictionsModel, *CustomErrorModel, error) {
+	if restrictions == nil {
+		return nil, nil, errors.New("не заданы ограничения доставки")
+	}
+	var out BaseDeliveryRestrictionsModel
+	if cerr, err := d.client.queryFor(ctx, "/api/1/delivery_restrictions/update", restrictions.OrganizationID, restrictions, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Allowed реплицирует DeliveryRestrictions.allowed: можно ли доставить заказ по адресу или координатам
+func (d *DeliveryRestrictions) Allowed(ctx context.Context, organizationIDs []string, req *DeliveryAllowedRequestModel) (*DeliveryAllowedModel, *CustomErrorModel, error) {
+	if req == nil {
+		return nil, nil, errors.New("не задан запрос проверки доставки")
+	}
+	data, err := requestData(req, map[string]any{"organizationIds": organizationIDs})
+	if err != nil {
+		return nil, nil, err
+	}
+	var out DeliveryAllowedModel
+	if cerr, err := d.client.query(ctx, "/api/1/delivery_restrictions/allowed", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// NewRestrictionAddress собирает адрес для Allowed из справочников Address.Cities и Address.StreetsByCity
+func NewRestrictionAddress(city CitiesItemModel, street StreetsItemModel, house string) *DeliveryRestrictionAddressModel {
+	streetID := street.ID
+	return &DeliveryRestrictionAddressModel{
+		City:       city.Name,
+		StreetName: street.Name,
+		StreetID:   &streetID,
+		House:      house,
+	}
+}
+
+// DeliveryAddress адрес в формате заказа доставки (DeliveryPointRequestModel.Address)
+func (a *DeliveryRestrictionAddressModel) DeliveryAddress() *DeliveryAddressRequestModel {
+	street := DeliveryStreetRequestModel{ID: a.StreetID}
+	if a.StreetID == nil {
+		name, city := a.StreetName, a.City
+		street.Name, street.City = &name, &city
+	}
+	return &DeliveryAddressRequestModel{
+		Street:   street,
+		Index:    a.Index,
+		House:    a.House,
+		Building: a.Building,
+	}
+}
+
+// ZonesAt возвращает зоны доставки организации, в которые попадает точка
+func (m *DeliveryRestrictionModel) ZonesAt(point CoordinatesModel) []DeliveryZoneModel {
+	var out []DeliveryZoneModel
+	for _, z := range m.DeliveryZones {
+		if z.Contains(point) {
+			out = append(out, z)
+		}
+	}
+	return out
+}
+
+// Contains проверяет попадание точки в зону: в любой полигон или круг зоны.
+// Coordinates без Polygons трактуется как единственный полигон.
+func (z DeliveryZoneModel) Contains(point CoordinatesModel) bool {
+	if len(z.Polygons) == 0 && len(z.Coordinates) > 2 && polygonContains(z.Coordinates, point) {
+		return true
+	}
+	for _, p := range z.Polygons {
+		if polygonContains(p, point) {
+			return true
+		}
+	}
+	for _, c := range z.Circles {
+		if distanceKm(c.Center, point) <= c.Radius {
+			return true
+		}
+	}
+	return false
+}
+
+// polygonContains проверка точки в многоугольнике методом луча
+func polygonContains(polygon []CoordinatesModel, p CoordinatesModel) bool {
+	inside := false
+	for i, j := 0, len(polygon)-1; i < len(polygon); j, i = i, i+1 {
+		a, b := polygon[i], polygon[j]
+		if (a.Latitude > p.Latitude) != (b.Latitude > p.Latitude) &&
+			p.Longitude < (b.Longitude-a.Longitude)*(p.Latitude-a.Latitude)/(b.Latitude-a.Latitude)+a.Longitude {
+			inside = !inside
+		}
+	}
+	return inside
+}
+
+// distanceKm расстояние между точками по формуле гаверсинусов
+func distanceKm(a, b CoordinatesModel) float64 {
+	const earthRadiusKm = 6371.0
+	toRad := math.Pi / 180
+	dLat := (b.Latitude - a.Latitude) * toRad
+	dLon := (b.Longitude - a.Longitude) * toRad
+	h := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(a.Latitude*toRad)*math.Cos(b.Latitude*toRad)*math.Sin(dLon/2)*math.Sin(dLon/2)
+	return 2 * earthRadiusKm * math.Asin(math.Sqrt(h))
+}
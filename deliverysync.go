@@ -0,0 +1,178 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+	"sync"
+)
+
+// DeliveryDiff изменение заказа доставки, найденное DeliverySyncer
+type DeliveryDiff struct {
+	OrganizationID string
+	OrderID        string
+	// Previous предыдущее состояние из снимка, nil для нового заказа
+	Previous *ByOrderItemModel
+	// Current состояние из последнего ответа by_revision
+	Current ByOrderItemModel
+
+	Created         bool
+	StatusChanged   bool
+	PreviousStatus  string
+	Status          string
+	CourierAssigned bool
+	// CourierID текущий курьер заказа (пусто, если курьер не назначен)
+	CourierID    string
+	ItemsChanged bool
+}
+
+// DeliverySyncer инкрементально синхронизирует заказы доставки через by_revision:
+// хранит последнюю ревизию по каждой организации и локальный снимок заказов.
+// Безопасен для вызова из нескольких горутин.
+type DeliverySyncer struct {
+	deliveries      IDeliveries
+	organizationIDs []string
+	sourceKeys      []string
+
+	// tickMu сериализует Tick: запросы и применение ответа выполняются без гонок между вызовами
+	tickMu sync.Mutex
+
+	mu        sync.Mutex
+	revisions map[string]int64
+	snapshot  map[string]ByOrderItemModel
+}
+
+// NewDeliverySyncer создает синхронизатор заказов организаций organizationIDs
+func NewDeliverySyncer(deliveries IDeliveries, organizationIDs []string, sourceKeys ...string) *DeliverySyncer {
+	return &DeliverySyncer{
+		deliveries:      deliveries,
+		organizationIDs: organizationIDs,
+		sourceKeys:      sourceKeys,
+		revisions:       map[string]int64{},
+		snapshot:        map[string]ByOrderItemModel{},
+	}
+}
+
+// Revision возвращает последнюю полученную ревизию организации
+func (s *DeliverySyncer) Revision(organizationID string) int64 {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.revisions[organizationID]
+}
+
+// SetRevision задает стартовую ревизию организации, например восстановленную после перезапуска
+func (s *DeliverySyncer) SetRevision(organizationID string, revision int64) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.revisions[organizationID] = revision
+}
+
+// Order возвращает заказ из локального снимка
+func (s *DeliverySyncer) Order(orderID string) (ByOrderItemModel, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	o, ok := s.snapshot[orderID]
+	return o, ok
+}
+
+// Snapshot возвращает копию локального снимка заказов
+func (s *DeliverySyncer) Snapshot() []ByOrderItemModel {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	out := make([]ByOrderItemModel, 0, len(s.snapshot))
+	for _, o := range s.snapshot {
+		out = append(out, o)
+	}
+	return out
+}
+
+// Tick запрашивает изменения после сохраненных ревизий и возвращает изменения заказов.
+// Снимок и ревизии обновляются, только если ответили все организации: при ошибке
+// ничего не применяется, и следующий Tick повторит запросы с тех же ревизий.
+// Закрытые и отмененные заказы возвращаются в изменениях и удаляются из снимка.
+// Параллельные вызовы Tick выполняются по очереди.
+func (s *DeliverySyncer) Tick(ctx context.Context) ([]DeliveryDiff, *CustomErrorModel, error) {
+	s.tickMu.Lock()
+	defer s.tickMu.Unlock()
+	responses := make([]*ByDeliveryDateAndStatusModel, len(s.organizationIDs))
+	for i, orgID := range s.organizationIDs {
+		resp, cerr, err := s.deliveries.ByRevision(ctx, []string{orgID}, s.Revision(orgID), s.sourceKeys)
+		if cerr != nil || err != nil {
+			return nil, cerr, err
+		}
+		responses[i] = resp
+	}
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	var diffs []DeliveryDiff
+	for i, orgID := range s.organizationIDs {
+		resp := responses[i]
+		for _, byOrg := range resp.OrdersByOrganizations {
+			for _, o := range byOrg.Orders {
+				if o.OrganizationID == "" {
+					o.OrganizationID = byOrg.OrganizationID
+				}
+				diffs = append(diffs, s.apply(o))
+			}
+		}
+		if rev := int64(resp.MaxRevision); rev > s.revisions[orgID] {
+			s.revisions[orgID] = rev
+		}
+	}
+	return diffs, nil, nil
+}
+
+// apply сравнивает заказ со снимком и обновляет снимок; вызывается под s.mu
+func (s *DeliverySyncer) apply(cur ByOrderItemModel) DeliveryDiff {
+	d := DeliveryDiff{
+		OrganizationID: cur.OrganizationID,
+		OrderID:        cur.ID,
+		Current:        cur,
+		Status:         deliveryStatus(cur),
+		CourierID:      deliveryCourierID(cur),
+	}
+	prev, ok := s.snapshot[cur.ID]
+	if deliveryFinished(d.Status) {
+		delete(s.snapshot, cur.ID)
+	} else {
+		s.snapshot[cur.ID] = cur
+	}
+	if !ok {
+		d.Created = true
+		d.CourierAssigned = d.CourierID != ""
+		return d
+	}
+	d.Previous = &prev
+	d.PreviousStatus = deliveryStatus(prev)
+	d.StatusChanged = d.PreviousStatus != d.Status
+	d.CourierAssigned = d.CourierID != "" && d.CourierID != deliveryCourierID(prev)
+	d.ItemsChanged = deliveryItemsKey(prev) != deliveryItemsKey(cur)
+	return d
+}
+
+// deliveryFinished заказ больше не изменится и не нужен в снимке
+func deliveryFinished(status string) bool {
+	return status == "Closed" || status == "Cancelled"
+}
+
+func deliveryStatus(o ByOrderItemModel) string {
+	if o.Order == nil {
+		return ""
+	}
+	return o.Order.Status
+}
+
+func deliveryCourierID(o ByOrderItemModel) string {
+	if o.Order == nil || o.Order.CourierInfo == nil {
+		return ""
+	}
+	return o.Order.CourierInfo.Courier.ID
+}
+
+// deliveryItemsKey отпечаток позиций и комбо заказа для сравнения
+func deliveryItemsKey(o ByOrderItemModel) string {
+	if o.Order == nil {
+		return ""
+	}
+	b, _ := json.Marshal([]any{o.Order.Items, o.Order.Combos})
+	return string(b)
+}
@@ -0,0 +1,157 @@
+package goiikoapi
+
+import (
+	"context"
+	"errors"
+	"testing"
+)
+
+func testDelivery(id, status, courierID string, items ...string) ByOrderItemModel {
+	order := &CreatedDeliveryOrderModel{Status: status}
+	if courierID != "" {
+		order.CourierInfo = &CourierInfoModel{Courier: EmployeeModel{ID: courierID}}
+	}
+	for _, item := range items {
+		order.Items = append(order.Items, OrderProductItemModel{Product: IdNameModel{ID: item}})
+	}
+	return ByOrderItemModel{ID: id, OrganizationID: "org", Order: order}
+}
+
+func TestDeliverySyncerApply(t *testing.T) {
+	tests := []struct {
+		name     string
+		previous *ByOrderItemModel
+		current  ByOrderItemModel
+		want     DeliveryDiff
+		inSnap   bool
+	}{
+		{
+			name:    "new order",
+			current: testDelivery("1", "Unconfirmed", ""),
+			want:    DeliveryDiff{Created: true, Status: "Unconfirmed"},
+			inSnap:  true,
+		},
+		{
+			name:    "new order with courier",
+			current: testDelivery("1", "OnWay", "c1"),
+			want:    DeliveryDiff{Created: true, Status: "OnWay", CourierAssigned: true, CourierID: "c1"},
+			inSnap:  true,
+		},
+		{
+			name:     "status changed",
+			previous: ptrDelivery(testDelivery("1", "Unconfirmed", "")),
+			current:  testDelivery("1", "CookingStarted", ""),
+			want:     DeliveryDiff{StatusChanged: true, PreviousStatus: "Unconfirmed", Status: "CookingStarted"},
+			inSnap:   true,
+		},
+		{
+			name:     "courier assigned",
+			previous: ptrDelivery(testDelivery("1", "Waiting", "")),
+			current:  testDelivery("1", "Waiting", "c1"),
+			want:     DeliveryDiff{PreviousStatus: "Waiting", Status: "Waiting", CourierAssigned: true, CourierID: "c1"},
+			inSnap:   true,
+		},
+		{
+			name:     "same courier",
+			previous: ptrDelivery(testDelivery("1", "OnWay", "c1")),
+			current:  testDelivery("1", "OnWay", "c1"),
+			want:     DeliveryDiff{PreviousStatus: "OnWay", Status: "OnWay", CourierID: "c1"},
+			inSnap:   true,
+		},
+		{
+			name:     "items changed",
+			previous: ptrDelivery(testDelivery("1", "New", "", "soup")),
+			current:  testDelivery("1", "New", "", "soup", "tea"),
+			want:     DeliveryDiff{PreviousStatus: "New", Status: "New", ItemsChanged: true},
+			inSnap:   true,
+		},
+		{
+			name:     "closed order leaves snapshot",
+			previous: ptrDelivery(testDelivery("1", "Delivered", "c1")),
+			current:  testDelivery("1", "Closed", "c1"),
+			want:     DeliveryDiff{StatusChanged: true, PreviousStatus: "Delivered", Status: "Closed", CourierID: "c1"},
+		},
+		{
+			name:    "cancelled unknown order",
+			current: testDelivery("1", "Cancelled", ""),
+			want:    DeliveryDiff{Created: true, Status: "Cancelled"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			s := NewDeliverySyncer(nil, nil)
+			if tt.previous != nil {
+				s.snapshot[tt.previous.ID] = *tt.previous
+			}
+			got := s.apply(tt.current)
+			if (got.Previous != nil) != (tt.previous != nil) {
+				t.Errorf("Previous = %v, want set: %v", got.Previous, tt.previous != nil)
+			}
+			got.Previous, got.Current, got.OrganizationID, got.OrderID = nil, ByOrderItemModel{}, "", ""
+			if got != tt.want {
+				t.Errorf("apply() = %+v, want %+v", got, tt.want)
+			}
+			if _, ok := s.Order(tt.current.ID); ok != tt.inSnap {
+				t.Errorf("order in snapshot = %v, want %v", ok, tt.inSnap)
+			}
+		})
+	}
+}
+
+func ptrDelivery(o ByOrderItemModel) *ByOrderItemModel {
+	return &o
+}
+
+// fakeDeliveries отвечает на ByRevision заранее заданными ответами по организациям
+type fakeDeliveries struct {
+	IDeliveries
+	responses map[string]*ByDeliveryDateAndStatusModel
+	errs      map[string]error
+	calls     map[string][]int64
+}
+
+func (f *fakeDeliveries) ByRevision(ctx context.Context, organizationIDs []string, startRevision int64, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error) {
+	orgID := organizationIDs[0]
+	f.calls[orgID] = append(f.calls[orgID], startRevision)
+	if err := f.errs[orgID]; err != nil {
+		return nil, nil, err
+	}
+	return f.responses[orgID], nil, nil
+}
+
+func TestDeliverySyncerTick(t *testing.T) {
+	f := &fakeDeliveries{
+		responses: map[string]*ByDeliveryDateAndStatusModel{
+			"a": {MaxRevision: 10, OrdersByOrganizations: []OrdersByOrganizationsModel{{OrganizationID: "a", Orders: []ByOrderItemModel{{ID: "1", Order: &CreatedDeliveryOrderModel{Status: "New"}}}}}},
+			"b": {MaxRevision: 7},
+		},
+		errs:  map[string]error{"b": errors.New("unavailable")},
+		calls: map[string][]int64{},
+	}
+	s := NewDeliverySyncer(f, []string{"a", "b"})
+
+	if _, _, err := s.Tick(context.Background()); err == nil {
+		t.Fatal("Tick() error = nil, want error")
+	}
+	if _, ok := s.Order("1"); ok || s.Revision("a") != 0 {
+		t.Fatal("failed Tick must not change snapshot or revisions")
+	}
+
+	delete(f.errs, "b")
+	diffs, _, err := s.Tick(context.Background())
+	if err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if len(diffs) != 1 || !diffs[0].Created || diffs[0].OrganizationID != "a" {
+		t.Fatalf("Tick() diffs = %+v", diffs)
+	}
+	if s.Revision("a") != 10 || s.Revision("b") != 7 {
+		t.Fatalf("revisions = %d, %d, want 10, 7", s.Revision("a"), s.Revision("b"))
+	}
+	if _, _, err := s.Tick(context.Background()); err != nil {
+		t.Fatalf("Tick() error = %v", err)
+	}
+	if got := f.calls["a"]; len(got) != 3 || got[0] != 0 || got[1] != 0 || got[2] != 10 {
+		t.Fatalf("ByRevision start revisions = %v, want [0 0 10]", got)
+	}
+}
@@ -21,8 +21,8 @@ func (d *Dictionaries) OrderTypes(ctx context.Context, organizationIDs []string)
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := d.client.post(ctx, "/api/1/deliveries/order_types", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/deliveries/order_types", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseOrderTypesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -37,8 +37,8 @@ func (d *Dictionaries) PaymentTypes(ctx context.Context, organizationIDs []strin
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := d.client.post(ctx, "/api/1/payment_types", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/payment_types", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BasePaymentTypesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -53,8 +53,8 @@ func (d *Dictionaries) Discounts(ctx context.Context, organizationIDs []string)
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := d.client.post(ctx, "/api/1/discounts", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/discounts", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseDiscountsModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -69,8 +69,8 @@ func (d *Dictionaries) CancelCauses(ctx context.Context, organizationIDs []strin
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := d.client.post(ctx, "/api/1/cancel_causes", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/cancel_causes", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseCancelCausesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -85,8 +85,8 @@ func (d *Dictionaries) RemovalTypes(ctx context.Context, organizationIDs []strin
 	data := map[string]any{"organizationIds": organizationIDs}
 	body, status, err := d.client.post(ctx, "/api/1/removal_types", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/removal_types", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseRemovalTypesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -97,8 +97,8 @@ func (d *Dictionaries) RemovalTypes(ctx context.Context, organizationIDs []strin
 func (d *Dictionaries) TipsTypes(ctx context.Context) (*BaseTipsTypesModel, *CustomErrorModel, error) {
 	body, status, err := d.client.post(ctx, "/api/1/tips_types", map[string]any{})
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/tips_types", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseTipsTypesModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
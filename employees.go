@@ -25,8 +25,8 @@ func (e *Employees) Couriers(ctx context.Context, organizationIDs []string) (*Ba
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/couriers", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseCouriersModel
 	if err := json.Unmarshal(body, &out); err != nil {
@@ -45,8 +45,8 @@ func (e *Employees) EmployeeInfo(ctx context.Context, organizationID, id string)
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/info", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseEmployeeInfoModel
 	if err := json.Unmarshal(body, &out); err != nil {
@@ -72,13 +72,16 @@ func (e *Employees) ShiftClockin(ctx context.Context, organizationID, terminalGr
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/shift/clockin", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil {
 		return nil, nil, err
 	}
+	if cerr, err := e.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil {
+		return &out, cerr, err
+	}
 	return &out, nil, nil
 }
 
@@ -96,13 +99,16 @@ func (e *Employees) ShiftClockout(ctx context.Context, organizationID, terminalG
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/shift/clockout", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil {
 		return nil, nil, err
 	}
+	if cerr, err := e.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil {
+		return &out, cerr, err
+	}
 	return &out, nil, nil
 }
 
@@ -118,8 +124,8 @@ func (e *Employees) ShiftIsOpen(ctx context.Context, organizationID, terminalGro
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/shift/is_open", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseEmployeeInfoModel
 	if err := json.Unmarshal(body, &out); err != nil {
@@ -138,8 +144,8 @@ func (e *Employees) ShiftByCourier(ctx context.Context, employeeID string) (*Bas
 	if err != nil {
 		return nil, nil, err
 	}
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/employees/shift/by_courier", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseEmployeeTerminalModel
 	if err := json.Unmarshal(body, &out); err != nil {
@@ -147,3 +153,45 @@ func (e *Employees) ShiftByCourier(ctx context.Context, employeeID string) (*Bas
 	}
 	return &out, nil, nil
 }
+
+// CouriersActiveLocation реплицирует Employees.couriers_active_location: последние координаты активных курьеров
+func (e *Employees) CouriersActiveLocation(ctx context.Context, organizationIDs, terminalGroupIDs []string) (*BaseActiveCourierLocationsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+	}
+	if len(terminalGroupIDs) > 0 {
+		data["terminalGroupIds"] = terminalGroupIDs
+	}
+	var out BaseActiveCourierLocationsModel
+	if cerr, err := e.client.query(ctx, "/api/1/employees/couriers/active_location", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CouriersLocationsByTimeOffset реплицирует Employees.couriers_locations_by_time_offset:
+// треки курьеров за последние offsetInSeconds секунд
+func (e *Employees) CouriersLocationsByTimeOffset(ctx context.Context, organizationIDs []string, offsetInSeconds int) (*BaseCourierLocationsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"offsetInSeconds": offsetInSeconds,
+	}
+	var out BaseCourierLocationsModel
+	if cerr, err := e.client.query(ctx, "/api/1/employees/couriers/locations/by_time_offset", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CouriersByRole реплицирует Employees.couriers_by_role: сотрудники с ролями из rolesToCheck (коды ролей)
+func (e *Employees) CouriersByRole(ctx context.Context, organizationIDs, rolesToCheck []string) (*BaseCouriersModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"rolesToCheck":    rolesToCheck,
+	}
+	var out BaseCouriersModel
+	if cerr, err := e.client.query(ctx, "/api/1/employees/couriers/by_role", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
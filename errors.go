@@ -0,0 +1,141 @@
+package goiikoapi
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+)
+
+// Категории ошибок iiko для errors.Is
+var (
+	ErrUnauthorized = errors.New("iiko: unauthorized")
+	ErrRateLimited  = errors.New("iiko: rate limited")
+	ErrNotFound     = errors.New("iiko: not found")
+	ErrValidation   = errors.New("iiko: validation error")
+	ErrServer       = errors.New("iiko: server error")
+)
+
+// APIError ошибка, возвращенная iiko Cloud API
+type APIError struct {
+	StatusCode    int
+	Code          string
+	Description   string
+	CorrelationID string
+	Endpoint      string
+	Body          []byte
+}
+
+func (e *APIError) Error() string {
+	msg := e.Description
+	if msg == "" {
+		msg = http.StatusText(e.StatusCode)
+	}
+	if e.Code != "" {
+		msg = e.Code + ": " + msg
+	}
+	return fmt.Sprintf("iiko %s (%d): %s", e.Endpoint, e.StatusCode, msg)
+}
+
+// Category возвращает одну из ErrUnauthorized, ErrRateLimited, ErrNotFound, ErrValidation, ErrServer или nil
+func (e *APIError) Category() error {
+	switch {
+	case e.StatusCode == http.StatusUnauthorized || e.StatusCode == http.StatusForbidden:
+		return ErrUnauthorized
+	case e.StatusCode == http.StatusTooManyRequests:
+		return ErrRateLimited
+	case e.StatusCode == http.StatusNotFound:
+		return ErrNotFound
+	case e.StatusCode >= 500:
+		return ErrServer
+	case e.StatusCode >= 400, e.Description != "":
+		return ErrValidation
+	}
+	return nil
+}
+
+// Is позволяет сравнивать APIError с категориями через errors.Is
+func (e *APIError) Is(target error) bool {
+	c := e.Category()
+	return c != nil && c == target
+}
+
+// ParseAPIError строит APIError из ответа iiko. ok == false, если ответ не является ошибкой
+func ParseAPIError(endpoint string, status int, body []byte) (*APIError, bool) {
+	var env ErrorModel
+	_ = json.Unmarshal(body, &env)
+	if env.ErrorDescription == "" && status < http.StatusBadRequest {
+		return nil, false
+	}
+	return &APIError{
+		StatusCode:    status,
+		Code:          env.Error,
+		Description:   env.ErrorDescription,
+		CorrelationID: env.CorrelationID,
+		Endpoint:      endpoint,
+		Body:          body,
+	}, true
+}
+
+// detectCustomError возвращает заполненный CustomErrorModel, если ответ является ошибкой iiko
+func detectCustomError(endpoint string, status int, body []byte) *CustomErrorModel {
+	apiErr, ok := ParseAPIError(endpoint, status, body)
+	if !ok {
+		return nil
+	}
+	return apiErr.CustomError()
+}
+
+// CustomError конвертирует APIError в CustomErrorModel для тройного возврата
+func (e *APIError) CustomError() *CustomErrorModel {
+	return &CustomErrorModel{
+		ErrorModel: ErrorModel{
+			BaseResponseModel: BaseResponseModel{CorrelationID: e.CorrelationID},
+			ErrorDescription:  e.Description,
+			Error:             e.Code,
+		},
+		StatusCode: e.StatusCode,
+		Endpoint:   e.Endpoint,
+		Body:       e.Body,
+	}
+}
+
+// AsAPIError конвертирует CustomErrorModel в APIError. Для nil возвращает nil
+func (e *CustomErrorModel) AsAPIError() *APIError {
+	if e == nil {
+		return nil
+	}
+	return &APIError{
+		StatusCode:    e.StatusCode,
+		Code:          e.ErrorModel.Error,
+		Description:   e.ErrorDescription,
+		CorrelationID: e.CorrelationID,
+		Endpoint:      e.Endpoint,
+		Body:          e.Body,
+	}
+}
+
+// Err возвращает ошибку iiko как error (nil для nil-модели)
+func (e *CustomErrorModel) Err() error {
+	if e == nil {
+		return nil
+	}
+	return e.AsAPIError()
+}
+
+// Result сводит тройку (data, *CustomErrorModel, error) любого метода к паре (data, error),
+// где ошибка iiko представлена как *APIError:
+//
+//	orgs, err := goiikoapi.Result(cli.Organizations(ctx, nil, nil, nil))
+//	if errors.Is(err, goiikoapi.ErrUnauthorized) { ... }
+func Result[T any](data T, apiErr *CustomErrorModel, err error) (T, error) {
+	if err != nil {
+		var zero T
+		return zero, err
+	}
+	if apiErr != nil {
+		var zero T
+		return zero, apiErr.AsAPIError()
+	}
+	return data, nil
+}
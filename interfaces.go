@@ -1,6 +1,9 @@
 package goiikoapi
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 // IDictionaries интерфейс для работы со словарями
 type IDictionaries interface {
@@ -22,16 +25,38 @@ type IMenu interface {
 // IOrders интерфейс для работы с заказами
 type IOrders interface {
 	OrderCreate(ctx context.Context, organizationID, terminalGroupID string, order map[string]any, createOrderSettings *int) (*BaseCreatedOrderInfoModel, *CustomErrorModel, error)
+	OrderCreateTyped(ctx context.Context, organizationID, terminalGroupID string, order *OrderRequestModel, createOrderSettings *int) (*BaseCreatedOrderInfoModel, *CustomErrorModel, error)
 	OrderByID(ctx context.Context, organizationIDs []string, orderIDs, posOrderIDs, returnExternalDataKeys, sourceKeys []string) (*ByIdModel, *CustomErrorModel, error)
+	AddItems(ctx context.Context, organizationID, orderID string, items []OrderItemRequestModel, combos []OrderComboRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	AddPayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangePayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	Close(ctx context.Context, organizationID, orderID string, chequeAdditionalInfo *ChequeAdditionalInfoModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ByTable(ctx context.Context, organizationIDs, tableIDs, statuses []string, dateFrom, dateTo *string, sourceKeys []string) (*ByTableModel, *CustomErrorModel, error)
+	InitByTable(ctx context.Context, organizationID, terminalGroupID string, tableIDs []string) (*BaseResponseModel, *CustomErrorModel, error)
+	InitByPosOrder(ctx context.Context, organizationID, terminalGroupID string, posOrderIDs []string) (*BaseResponseModel, *CustomErrorModel, error)
 }
 
 // IDeliveries интерфейс для работы с доставкой
 type IDeliveries interface {
 	DeliveryCreate(ctx context.Context, organizationID string, order map[string]any, terminalGroupID *string, createOrderSettings *int) (*BaseCreatedDeliveryOrderInfoModel, *CustomErrorModel, error)
+	DeliveryCreateTyped(ctx context.Context, organizationID string, order *OrderRequestModel, terminalGroupID *string, createOrderSettings *int) (*BaseCreatedDeliveryOrderInfoModel, *CustomErrorModel, error)
 	UpdateOrderDeliveryStatus(ctx context.Context, organizationID string, orderID string, deliveryStatus string, deliveryDate *string) (*BaseResponseModel, *CustomErrorModel, error)
 	Confirm(ctx context.Context, organizationID string, orderID string) (*BaseResponseModel, *CustomErrorModel, error)
 	CancelConfirmation(ctx context.Context, organizationIDs []string, orderID string) (*BaseResponseModel, *CustomErrorModel, error)
+	AddItems(ctx context.Context, organizationID, orderID string, items []OrderItemRequestModel, combos []OrderComboRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	Close(ctx context.Context, organizationID, orderID string, deliveryDate *string) (*BaseResponseModel, *CustomErrorModel, error)
+	Cancel(ctx context.Context, organizationID, orderID string, cancel *DeliveryCancelRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeCompleteBefore(ctx context.Context, organizationID, orderID string, completeBefore time.Time) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeDeliveryPoint(ctx context.Context, organizationID, orderID string, deliveryPoint *DeliveryPointRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeServiceType(ctx context.Context, organizationID, orderID, serviceType string, deliveryPoint *DeliveryPointRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangePayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeComment(ctx context.Context, organizationID, orderID, comment string) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeDriverInfo(ctx context.Context, organizationID, orderID, driverID string, estimatedTime *time.Time) (*BaseResponseModel, *CustomErrorModel, error)
+	UpdateOrderProblem(ctx context.Context, organizationID, orderID string, hasProblem bool, problem *string) (*BaseResponseModel, *CustomErrorModel, error)
+	PrintDeliveryBill(ctx context.Context, organizationID, orderID string) (*BaseResponseModel, *CustomErrorModel, error)
 	ByDeliveryDateAndStatus(ctx context.Context, organizationIDs []string, deliveryDateFrom, deliveryDateTo string, statuses, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error)
+	ByDeliveryDateAndPhone(ctx context.Context, organizationIDs []string, phone, deliveryDateFrom, deliveryDateTo string, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error)
+	ByRevision(ctx context.Context, organizationIDs []string, startRevision int64, sourceKeys []string) (*ByDeliveryDateAndStatusModel, *CustomErrorModel, error)
 	ByDeliveryDateAndSourceKeyAndFilter(ctx context.Context, organizationIDs []string, terminalGroupIDs []string, deliveryDateFrom, deliveryDateTo *string, statuses []string, hasProblem *bool, orderServiceType, searchText *string, timeToCookingErrorTimeout, cookingTimeout *int, sortProperty, sortDirection *string, rowsCount *int, sourceKeys, orderIDs []string) (*ByDeliveryDateAndSourceKeyAndFilter, *CustomErrorModel, error)
 }
 
@@ -59,6 +84,11 @@ type ICustomers interface {
 	CustomerWalletCancelHold(ctx context.Context, organizationID, transactionID string) (*BaseResponseModel, *CustomErrorModel, error)
 	CustomerWalletTopup(ctx context.Context, customerID, walletID, organizationID string, sum float64, comment *string) (*BaseResponseModel, *CustomErrorModel, error)
 	CustomerWalletChargeoff(ctx context.Context, customerID, walletID, organizationID string, sum float64, comment *string) (*BaseResponseModel, *CustomErrorModel, error)
+	CustomerCategories(ctx context.Context, organizationID string) (*CustomerCategoriesModel, *CustomErrorModel, error)
+	CustomerCategoryAdd(ctx context.Context, customerID, categoryID, organizationID string) (*BaseResponseModel, *CustomErrorModel, error)
+	CustomerCategoryRemove(ctx context.Context, customerID, categoryID, organizationID string) (*BaseResponseModel, *CustomErrorModel, error)
+	CustomerTransactionsByDate(ctx context.Context, customerID, organizationID, dateFrom, dateTo string, pageNumber, pageSize int) (*CustomerTransactionsModel, *CustomErrorModel, error)
+	CustomerTransactionsByRevision(ctx context.Context, customerID, organizationID string, revision int64, pageSize int) (*CustomerTransactionsModel, *CustomErrorModel, error)
 }
 
 // INotifications интерфейс для работы с уведомлениями
@@ -69,12 +99,65 @@ type INotifications interface {
 // ICommands интерфейс для работы с командами
 type ICommands interface {
 	Status(ctx context.Context, organizationID, correlationID string) (*BaseStatusModel, *CustomErrorModel, error)
+	WaitForCommand(ctx context.Context, organizationID, correlationID string, opts *WaitOptions) (*CommandResult, *CustomErrorModel, error)
 }
 
 // IWebHook интерфейс для работы с webhook'ами
 type IWebHook interface {
 	ParseWebhookOrder(data []map[string]any) ([]WebHookDeliveryOrderEventInfoModel, error)
-	ParseWebhookReserve(data []map[string]any) ([]WebHookDeliveryOrderEventInfoModel, error)
+	ParseWebhookReserve(data []map[string]any) ([]WebHookReserveEventInfoModel, error)
+	GetSettings(ctx context.Context, organizationID string) (*WebHookSettingsModel, *CustomErrorModel, error)
+	UpdateSettings(ctx context.Context, organizationID string, settings WebHookSettingsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	EnsureSettings(ctx context.Context, desired WebHookSettingsRequestModel) ([]WebHookEnsureResult, error)
+}
+
+// IReserves интерфейс для работы с резервами и банкетами
+type IReserves interface {
+	AvailableOrganizations(ctx context.Context, organizationIDs []string, includeDisabled *bool) (*BaseReserveOrganizationsModel, *CustomErrorModel, error)
+	AvailableTerminalGroups(ctx context.Context, organizationIDs []string) (*BaseTerminalGroupsModel, *CustomErrorModel, error)
+	AvailableRestaurantSections(ctx context.Context, terminalGroupIDs []string, returnSchema bool, revision *int64) (*BaseRestaurantSectionsModel, *CustomErrorModel, error)
+	RestaurantSectionsWorkload(ctx context.Context, restaurantSectionIDs []string, dateFrom time.Time, dateTo *time.Time) (*BaseSectionsWorkloadModel, *CustomErrorModel, error)
+	Create(ctx context.Context, organizationID, terminalGroupID string, reserve *ReserveRequestModel, createReserveSettings *int) (*BaseCreatedReserveInfoModel, *CustomErrorModel, error)
+	StatusByID(ctx context.Context, organizationID string, reserveIDs, sourceKeys []string) (*BaseReservesModel, *CustomErrorModel, error)
+	Cancel(ctx context.Context, organizationID, reserveID, cancelReason string) (*BaseResponseModel, *CustomErrorModel, error)
+	AddItems(ctx context.Context, organizationID, reserveID string, items []OrderItemRequestModel, combos []OrderComboRequestModel, guests *OrderGuestsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	AddPayments(ctx context.Context, organizationID, reserveID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeGuestsCount(ctx context.Context, organizationID, reserveID string, guestsCount int) (*BaseResponseModel, *CustomErrorModel, error)
+	ChangeStartTime(ctx context.Context, organizationID, reserveID string, estimatedStartTime time.Time, durationInMinutes *int) (*BaseResponseModel, *CustomErrorModel, error)
+}
+
+// IStopLists интерфейс для работы со стоп-листами
+type IStopLists interface {
+	StopLists(ctx context.Context, organizationIDs, terminalGroupIDs []string, returnSize bool) (*BaseStopListsModel, *CustomErrorModel, error)
+	Check(ctx context.Context, organizationID, terminalGroupID string, items []OrderItemRequestModel) (*BaseStopListCheckModel, *CustomErrorModel, error)
+	Add(ctx context.Context, organizationID, terminalGroupID string, items []StopListAddItemModel) (*BaseResponseModel, *CustomErrorModel, error)
+	Remove(ctx context.Context, organizationID, terminalGroupID string, items []StopListRemoveItemModel) (*BaseResponseModel, *CustomErrorModel, error)
+	Clear(ctx context.Context, organizationID, terminalGroupID string) (*BaseResponseModel, *CustomErrorModel, error)
+}
+
+// ILoyalty интерфейс для работы с программами лояльности iikoCard
+type ILoyalty interface {
+	Calculate(ctx context.Context, organizationID string, order *OrderRequestModel, params *LoyaltyCalculateRequestModel) (*LoyaltyCalculateModel, *CustomErrorModel, error)
+	Programs(ctx context.Context, organizationID string, withoutMarketingCampaigns bool) (*LoyaltyProgramsModel, *CustomErrorModel, error)
+	CouponInfo(ctx context.Context, organizationID, number string, series *string) (*CouponsInfoModel, *CustomErrorModel, error)
+	CouponSeries(ctx context.Context, organizationID string) (*CouponSeriesListModel, *CustomErrorModel, error)
+	ManualConditions(ctx context.Context, organizationID string) (*ManualConditionsModel, *CustomErrorModel, error)
+	Counters(ctx context.Context, organizationID string, guestIDs []string, periods, metrics []int) (*GuestCountersModel, *CustomErrorModel, error)
+	SendSMS(ctx context.Context, organizationID string, to MessageRecipient, msg MessageTemplate, data any) (*MessageStatusModel, *CustomErrorModel, error)
+	SendEmail(ctx context.Context, organizationID string, to MessageRecipient, msg MessageTemplate, data any) (*MessageStatusModel, *CustomErrorModel, error)
+}
+
+// ICombos интерфейс для работы с комбо
+type ICombos interface {
+	Combos(ctx context.Context, organizationID string, extraData bool) (*BaseCombosModel, *CustomErrorModel, error)
+	ComboPrice(ctx context.Context, organizationID string, items []ComboPriceItemRequestModel) (*ComboPriceModel, *CustomErrorModel, error)
+}
+
+// IDeliveryRestrictions интерфейс для работы с ограничениями доставки
+type IDeliveryRestrictions interface {
+	Restrictions(ctx context.Context, organizationIDs []string) (*BaseDeliveryRestrictionsModel, *CustomErrorModel, error)
+	Update(ctx context.Context, restrictions *DeliveryRestrictionModel) (*BaseDeliveryRestrictionsModel, *CustomErrorModel, error)
+	Allowed(ctx context.Context, organizationIDs []string, req *DeliveryAllowedRequestModel) (*DeliveryAllowedModel, *CustomErrorModel, error)
 }
 
 // IEmployees интерфейс для работы с сотрудниками
@@ -85,6 +168,9 @@ type IEmployees interface {
 	ShiftClockout(ctx context.Context, organizationID, terminalGroupID, employeeID string) (*BaseResponseModel, *CustomErrorModel, error)
 	ShiftIsOpen(ctx context.Context, organizationID, terminalGroupID, employeeID string) (*BaseEmployeeInfoModel, *CustomErrorModel, error)
 	ShiftByCourier(ctx context.Context, employeeID string) (*BaseEmployeeTerminalModel, *CustomErrorModel, error)
+	CouriersActiveLocation(ctx context.Context, organizationIDs, terminalGroupIDs []string) (*BaseActiveCourierLocationsModel, *CustomErrorModel, error)
+	CouriersLocationsByTimeOffset(ctx context.Context, organizationIDs []string, offsetInSeconds int) (*BaseCourierLocationsModel, *CustomErrorModel, error)
+	CouriersByRole(ctx context.Context, organizationIDs, rolesToCheck []string) (*BaseCouriersModel, *CustomErrorModel, error)
 }
 
 // IClient основной интерфейс клиента
@@ -92,6 +178,9 @@ type IClient interface {
 	// Базовые методы
 	Organizations(ctx context.Context, organizationIDs []string, returnAdditionalInfo, includeDisabled *bool) (*BaseOrganizationsModel, *CustomErrorModel, error)
 	LastDataRaw() []byte
+	Token() string
+	RefreshToken(ctx context.Context) error
+	InvalidateToken()
 
 	// Группы методов
 	GetDictionaries() IDictionaries
@@ -105,4 +194,9 @@ type IClient interface {
 	GetCommands() ICommands
 	GetWebHook() IWebHook
 	GetEmployees() IEmployees
+	GetReserves() IReserves
+	GetStopLists() IStopLists
+	GetLoyalty() ILoyalty
+	GetCombos() ICombos
+	GetDeliveryRestrictions() IDeliveryRestrictions
 }
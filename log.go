@@ -0,0 +1,213 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"net/http"
+	"strings"
+	"time"
+	"unicode/utf8"
+)
+
+// Logger минимальный структурный логгер: пары ключ-значение в args.
+type Logger interface {
+	Debug(msg string, args ...any)
+	Info(msg string, args ...any)
+	Error(msg string, args ...any)
+}
+
+// WithLogger подключает логгер запросов. Без WithDebug(true) логируются только метаданные:
+// метод, endpoint, статус, длительность и correlationId; с WithDebug(true) — также тела
+// запросов и ответов с маскированием чувствительных полей.
+func WithLogger(l Logger) Option {
+	return func(c *Client) { c.logger = l }
+}
+
+// NewStdLogger адаптирует *log.Logger к Logger (nil — log.Default())
+func NewStdLogger(l *log.Logger) Logger {
+	if l == nil {
+		l = log.Default()
+	}
+	return stdLogger{l: l}
+}
+
+type stdLogger struct {
+	l *log.Logger
+}
+
+func (s stdLogger) Debug(msg string, args ...any) { s.print("DEBUG", msg, args) }
+func (s stdLogger) Info(msg string, args ...any)  { s.print("INFO", msg, args) }
+func (s stdLogger) Error(msg string, args ...any) { s.print("ERROR", msg, args) }
+
+func (s stdLogger) print(level, msg string, args []any) {
+	var b strings.Builder
+	b.WriteString(level)
+	b.WriteByte(' ')
+	b.WriteString(msg)
+	for i := 0; i+1 < len(args); i += 2 {
+		fmt.Fprintf(&b, " %v=%v", args[i], args[i+1])
+	}
+	s.l.Print(b.String())
+}
+
+// RawResponse сырой ответ конкретного вызова (см. WithRawResponse)
+type RawResponse struct {
+	Endpoint   string
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+type rawResponseKey struct{}
+
+// WithRawResponse сохраняет в dst сырой ответ вызова, выполненного с возвращенным контекстом.
+// В отличие от LastDataRaw безопасен при параллельных запросах.
+func WithRawResponse(ctx context.Context, dst *RawResponse) context.Context {
+	return context.WithValue(ctx, rawResponseKey{}, dst)
+}
+
+func captureRawResponse(ctx context.Context, endpoint string, status int, header http.Header, body []byte) {
+	if dst, ok := ctx.Value(rawResponseKey{}).(*RawResponse); ok && dst != nil {
+		*dst = RawResponse{Endpoint: endpoint, StatusCode: status, Header: header, Body: body}
+	}
+}
+
+// logAttempt пишет в l одну попытку запроса
+func logAttempt(l Logger, logBodies bool, req *Request, reqBody []byte, resp *Response, latency time.Duration, err error) {
+	status := 0
+	var respBody []byte
+	if resp != nil {
+		status, respBody = resp.StatusCode, resp.Body
+	}
+	attempt := req.Attempt
+	if attempt == 0 {
+		attempt = 1
+	}
+	args := []any{
+		"method", http.MethodPost,
+		"endpoint", req.Endpoint,
+		"status", status,
+		"latency", latency,
+		"attempt", attempt,
+	}
+	if id := responseCorrelationID(respBody); id != "" {
+		args = append(args, "correlationId", id)
+	}
+	if logBodies {
+		args = append(args, "headers", redactHeader(req.Header), "request", redactBody(reqBody), "response", redactBody(respBody))
+	}
+	if err != nil {
+		l.Error("iiko request failed", append(args, "error", err)...)
+		return
+	}
+	if status >= http.StatusBadRequest {
+		l.Error("iiko request error", args...)
+		return
+	}
+	l.Debug("iiko request", args...)
+}
+
+func responseCorrelationID(body []byte) string {
+	var r BaseResponseModel
+	if json.Unmarshal(body, &r) != nil {
+		return ""
+	}
+	return r.CorrelationID
+}
+
+// maxLoggedBody ограничение длины тела в логе
+const maxLoggedBody = 4096
+
+// secretFields полностью скрываемые поля
+var secretFields = map[string]bool{
+	"apilogin":      true,
+	"clientsecret":  true,
+	"token":         true,
+	"password":      true,
+	"authorization": true,
+}
+
+// personalFields частично скрываемые поля (остаются последние символы)
+var personalFields = map[string]bool{
+	"phone":      true,
+	"cardtrack":  true,
+	"track":      true,
+	"cardnumber": true,
+	"email":      true,
+	"credential": true,
+}
+
+// redactBody возвращает JSON-тело для лога с замаскированными секретами и персональными данными
+func redactBody(body []byte) string {
+	if len(body) == 0 {
+		return ""
+	}
+	var v any
+	if err := json.Unmarshal(body, &v); err != nil {
+		return fmt.Sprintf("<не JSON, %d байт>", len(body))
+	}
+	b, err := json.Marshal(redactValue(v))
+	if err != nil {
+		return ""
+	}
+	if len(b) > maxLoggedBody {
+		// режем по границе символа, чтобы не разбить многобайтовую букву
+		n := maxLoggedBody
+		for n > 0 && !utf8.RuneStart(b[n]) {
+			n--
+		}
+		return string(b[:n]) + "…"
+	}
+	return string(b)
+}
+
+func redactValue(v any) any {
+	switch t := v.(type) {
+	case map[string]any:
+		for k, val := range t {
+			key := strings.ToLower(k)
+			switch {
+			case secretFields[key]:
+				t[k] = "***"
+			case personalFields[key]:
+				if s, ok := val.(string); ok {
+					t[k] = maskTail(s, 2)
+				} else {
+					t[k] = redactValue(val)
+				}
+			default:
+				t[k] = redactValue(val)
+			}
+		}
+		return t
+	case []any:
+		for i := range t {
+			t[i] = redactValue(t[i])
+		}
+		return t
+	}
+	return v
+}
+
+// maskTail заменяет звездочками все символы строки, кроме последних keep
+func maskTail(s string, keep int) string {
+	r := []rune(s)
+	if len(r) <= keep {
+		return strings.Repeat("*", len(r))
+	}
+	return strings.Repeat("*", len(r)-keep) + string(r[len(r)-keep:])
+}
+
+// redactHeader копия заголовков с замаскированным Authorization
+func redactHeader(h http.Header) http.Header {
+	if h == nil {
+		return nil
+	}
+	out := cloneHeader(h)
+	if out.Get("Authorization") != "" {
+		out.Set("Authorization", "Bearer ***")
+	}
+	return out
+}
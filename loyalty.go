@@ -0,0 +1,160 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+)
+
+// Loyalty содержит методы программ лояльности iikoCard: расчет скидок, программы, купоны, счетчики
+type Loyalty struct {
+	client *Client
+}
+
+// Проверяем, что Loyalty реализует ILoyalty
+var _ ILoyalty = (*Loyalty)(nil)
+
+// Calculate реплицирует Loyalty.calculate: скидки, бесплатные продукты и лимиты оплаты бонусами
+// для черновика заказа. Черновик собирается через NewOrderBuilder без оплат.
+func (l *Loyalty) Calculate(ctx context.Context, organizationID string, order *OrderRequestModel, params *LoyaltyCalculateRequestModel) (*LoyaltyCalculateModel, *CustomErrorModel, error) {
+	data := map[string]any{}
+	if params != nil {
+		// параметры расчета передаются на верхнем уровне запроса
+		b, err := json.Marshal(params)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, nil, err
+		}
+	}
+	data["organizationId"] = organizationID
+	data["order"] = order
+	var out LoyaltyCalculateModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/calculate", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Programs реплицирует Loyalty.program
+func (l *Loyalty) Programs(ctx context.Context, organizationID string, withoutMarketingCampaigns bool) (*LoyaltyProgramsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":            organizationID,
+		"withoutMarketingCampaigns": withoutMarketingCampaigns,
+	}
+	var out LoyaltyProgramsModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/program", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CouponInfo реплицирует Loyalty.coupons_info
+func (l *Loyalty) CouponInfo(ctx context.Context, organizationID, number string, series *string) (*CouponsInfoModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"number":         number,
+	}
+	if series != nil {
+		data["series"] = *series
+	}
+	var out CouponsInfoModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/coupons/info", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// CouponSeries реплицирует Loyalty.coupons_series: серии с неактивированными купонами
+func (l *Loyalty) CouponSeries(ctx context.Context, organizationID string) (*CouponSeriesListModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+	}
+	var out CouponSeriesListModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/coupons/series", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// ManualConditions реплицирует Loyalty.manual_condition
+func (l *Loyalty) ManualConditions(ctx context.Context, organizationID string) (*ManualConditionsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+	}
+	var out ManualConditionsModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/manual_condition", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Counters реплицирует Loyalty.get_counters; periods — CounterPeriod*, metrics — CounterMetric*
+func (l *Loyalty) Counters(ctx context.Context, organizationID string, guestIDs []string, periods, metrics []int) (*GuestCountersModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"guestIds":       guestIDs,
+		"periods":        periods,
+		"metrics":        metrics,
+	}
+	var out GuestCountersModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/get_counters", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// DiscountSum суммарная скидка по всем программам
+func (m *LoyaltyCalculateModel) DiscountSum() float64 {
+	var sum float64
+	for _, p := range m.LoyaltyProgramResults {
+		for _, d := range p.Discounts {
+			sum += d.DiscountSum
+		}
+	}
+	return sum
+}
+
+// MaxWalletSum максимальная сумма, которую можно списать с кошелька walletID
+func (m *LoyaltyCalculateModel) MaxWalletSum(walletID string) float64 {
+	var max float64
+	for _, p := range m.AvailablePayments {
+		for _, w := range p.WalletInfos {
+			if w.ID == walletID && w.MaxSum > max {
+				max = w.MaxSum
+			}
+		}
+	}
+	return max
+}
+
+// BonusPayment строит оплату бонусами iikoCard для заказа (см. OrderBuilder.AddPaymentModel).
+// credential — телефон гостя. Сумма ограничивается лимитом кошелька из расчета;
+// ok == false, если списание недоступно.
+func (m *LoyaltyCalculateModel) BonusPayment(paymentTypeID, walletID, credential string, sum float64) (payment OrderPaymentRequestModel, ok bool) {
+	if max := m.MaxWalletSum(walletID); sum > max {
+		sum = max
+	}
+	if sum <= 0 {
+		return OrderPaymentRequestModel{}, false
+	}
+	searchScope := "Phone"
+	return OrderPaymentRequestModel{
+		PaymentTypeKind: PaymentTypeKindIikoCard,
+		Sum:             sum,
+		PaymentTypeID:   paymentTypeID,
+		PaymentAdditionalData: &PaymentAdditionalDataRequestModel{
+			Type:        PaymentTypeKindIikoCard,
+			Credential:  &credential,
+			SearchScope: &searchScope,
+		},
+	}, true
+}
+
+// LoyaltyInfo данные лояльности для заказа: купон и ручные условия, использованные в расчете
+func (p *LoyaltyCalculateRequestModel) LoyaltyInfo() *LoyaltyInfoModel {
+	if p == nil || (p.Coupon == nil && len(p.ApplicableManualConditions) == 0) {
+		return nil
+	}
+	return &LoyaltyInfoModel{Coupon: p.Coupon, AppliedManualConditions: p.ApplicableManualConditions}
+}
@@ -21,8 +21,8 @@ func (m *Menu) Nomenclature(ctx context.Context, organizationID string, startRev
 	}
 	body, status, err := m.client.post(ctx, "/api/1/nomenclature", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/nomenclature", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseNomenclatureModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -33,8 +33,8 @@ func (m *Menu) Nomenclature(ctx context.Context, organizationID string, startRev
 func (m *Menu) Menu(ctx context.Context) (*BaseMenuModel, *CustomErrorModel, error) {
 	body, status, err := m.client.post(ctx, "/api/2/menu", map[string]any{})
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/2/menu", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseMenuModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -52,8 +52,8 @@ func (m *Menu) MenuByID(ctx context.Context, externalMenuID string, organization
 	}
 	body, status, err := m.client.post(ctx, "/api/2/menu/by_id", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/2/menu/by_id", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseMenuByIdModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
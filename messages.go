@@ -0,0 +1,127 @@
+package goiikoapi
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"text/template"
+)
+
+// ErrNoConsent гость не дал согласие на рекламные рассылки
+var ErrNoConsent = errors.New("iiko: клиент не дал согласие на рассылки")
+
+// MessageTemplate шаблон сообщения в синтаксисе text/template; Subject используется только для email
+type MessageTemplate struct {
+	Subject string
+	Text    string
+}
+
+// Render подставляет data в шаблон
+func (t MessageTemplate) Render(data any) (subject, text string, err error) {
+	if subject, err = renderTemplate("subject", t.Subject, data); err != nil {
+		return "", "", err
+	}
+	if text, err = renderTemplate("text", t.Text, data); err != nil {
+		return "", "", err
+	}
+	return subject, text, nil
+}
+
+// render подставляет data; при data == nil шаблон возвращается без обработки
+func (t MessageTemplate) render(data any) (subject, text string, err error) {
+	if data == nil {
+		return t.Subject, t.Text, nil
+	}
+	return t.Render(data)
+}
+
+func renderTemplate(name, src string, data any) (string, error) {
+	if src == "" {
+		return "", nil
+	}
+	tpl, err := template.New(name).Option("missingkey=error").Parse(src)
+	if err != nil {
+		return "", fmt.Errorf("шаблон %s: %w", name, err)
+	}
+	var b bytes.Buffer
+	if err := tpl.Execute(&b, data); err != nil {
+		return "", fmt.Errorf("шаблон %s: %w", name, err)
+	}
+	return b.String(), nil
+}
+
+// CanReceivePromo сообщает, что гость согласился на рекламные рассылки:
+// ShouldReceivePromoActionsInfo == true и ConsentStatus == ConsentStatusGiven
+func CanReceivePromo(customer *CustomerInfoModel) bool {
+	if customer == nil || customer.ShouldReceivePromoActionsInfo == nil {
+		return false
+	}
+	return *customer.ShouldReceivePromoActionsInfo && customer.ConsentStatus == ConsentStatusGiven
+}
+
+// SendSMS реплицирует Loyalty.send_sms. Текст берется из msg.Text, в который подставляется data
+// (data == nil — текст отправляется как есть). С to.RequireConsent сообщение гостю без согласия
+// на рассылки не отправляется: возвращается статус MessageStatusRefused и ErrNoConsent.
+func (l *Loyalty) SendSMS(ctx context.Context, organizationID string, to MessageRecipient, msg MessageTemplate, data any) (*MessageStatusModel, *CustomErrorModel, error) {
+	phone := to.Phone
+	if phone == "" && to.Customer != nil && to.Customer.Phone != nil {
+		phone = *to.Customer.Phone
+	}
+	status := &MessageStatusModel{Channel: MessageChannelSMS, Recipient: phone}
+	if to.RequireConsent && !CanReceivePromo(to.Customer) {
+		status.Status = MessageStatusRefused
+		return status, nil, ErrNoConsent
+	}
+	if phone == "" {
+		return nil, nil, errors.New("не задан телефон получателя")
+	}
+	_, text, err := msg.render(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := map[string]any{
+		"organizationId": organizationID,
+		"phone":          phone,
+		"text":           text,
+	}
+	var out BaseResponseModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/message/send_sms", req, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	status.Status, status.CorrelationID = MessageStatusAccepted, out.CorrelationID
+	return status, nil, nil
+}
+
+// SendEmail реплицирует Loyalty.send_email: тема из msg.Subject, тело (может содержать HTML) из msg.Text.
+// Подстановка data и проверка согласия — как в SendSMS.
+func (l *Loyalty) SendEmail(ctx context.Context, organizationID string, to MessageRecipient, msg MessageTemplate, data any) (*MessageStatusModel, *CustomErrorModel, error) {
+	email := to.Email
+	if email == "" && to.Customer != nil && to.Customer.Email != nil {
+		email = *to.Customer.Email
+	}
+	status := &MessageStatusModel{Channel: MessageChannelEmail, Recipient: email}
+	if to.RequireConsent && !CanReceivePromo(to.Customer) {
+		status.Status = MessageStatusRefused
+		return status, nil, ErrNoConsent
+	}
+	if email == "" {
+		return nil, nil, errors.New("не задан email получателя")
+	}
+	subject, body, err := msg.render(data)
+	if err != nil {
+		return nil, nil, err
+	}
+	req := map[string]any{
+		"organizationId": organizationID,
+		"receiver":       email,
+		"subject":        subject,
+		"body":           body,
+	}
+	var out BaseResponseModel
+	if cerr, err := l.client.query(ctx, "/api/1/loyalty/iiko/message/send_email", req, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	status.Status, status.CorrelationID = MessageStatusAccepted, out.CorrelationID
+	return status, nil, nil
+}
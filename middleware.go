@@ -0,0 +1,157 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"time"
+)
+
+// Request запрос к iiko, проходящий через цепочку middleware
+type Request struct {
+	// Endpoint путь метода, например "/api/1/order/create"
+	Endpoint string
+	// Group семейство методов: "order", "deliveries", "loyalty", ... (см. RateLimiterConfig.Families)
+	Group string
+	// OrganizationID организация запроса для RateLimiterConfig.PerOrganization, пусто если не определена
+	OrganizationID string
+	// Payload тело запроса до сериализации в JSON; middleware может его заменить
+	Payload any
+	// Header дополнительные заголовки запроса (трассировка, подпись)
+	Header http.Header
+	// Attempt номер попытки, начиная с 1 (выставляет RetryMiddleware)
+	Attempt int
+}
+
+// Response ответ iiko в цепочке middleware
+type Response struct {
+	StatusCode int
+	Header     http.Header
+	Body       []byte
+}
+
+// Decode разбирает тело ответа в v
+func (r *Response) Decode(v any) error {
+	return json.Unmarshal(r.Body, v)
+}
+
+// APIError возвращает ошибку iiko из ответа или nil
+func (r *Response) APIError(endpoint string) *APIError {
+	apiErr, ok := ParseAPIError(endpoint, r.StatusCode, r.Body)
+	if !ok {
+		return nil
+	}
+	return apiErr
+}
+
+// Handler выполняет запрос к iiko
+type Handler func(ctx context.Context, req *Request) (*Response, error)
+
+// Middleware оборачивает Handler. Может изменить запрос, ответ или вернуть ответ без вызова next.
+type Middleware func(next Handler) Handler
+
+// WithMiddleware добавляет middleware вокруг всех методов клиента. Первый middleware — внешний.
+// Встроенные повторы (WithRetryPolicy), ограничение частоты и лог выполняются внутри цепочки.
+func WithMiddleware(mw ...Middleware) Option {
+	return func(c *Client) { c.middlewares = append(c.middlewares, mw...) }
+}
+
+// buildHandler собирает цепочку: пользовательские middleware -> повторы -> лимитер -> лог -> HTTP
+func (c *Client) buildHandler() Handler {
+	h := Handler(c.transport)
+	if c.logger != nil {
+		h = LoggingMiddleware(c.logger, c.debug)(h)
+	}
+	if c.limiter != nil {
+		h = c.rateLimitMiddleware(h)
+	}
+	if c.retry != nil {
+		h = RetryMiddleware(c.retry)(h)
+	}
+	for i := len(c.middlewares) - 1; i >= 0; i-- {
+		h = c.middlewares[i](h)
+	}
+	return h
+}
+
+func (c *Client) rateLimitMiddleware(next Handler) Handler {
+	return func(ctx context.Context, req *Request) (*Response, error) {
+		if err := c.limiter.Wait(ctx, c.apiLogin, req.Endpoint, req.OrganizationID); err != nil {
+			return nil, err
+		}
+		return next(ctx, req)
+	}
+}
+
+// RetryMiddleware повторяет запрос по политике p (см. RetryPolicy)
+func RetryMiddleware(p *RetryPolicy) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			policy := p.forEndpoint(req.Endpoint)
+			for attempt := 1; ; attempt++ {
+				r := *req
+				r.Attempt = attempt
+				resp, err := next(ctx, &r)
+				status := 0
+				var header http.Header
+				if resp != nil {
+					status, header = resp.StatusCode, resp.Header
+				}
+				if !policy.shouldRetry(req.Endpoint, attempt, status, err) {
+					return resp, err
+				}
+				d, ok := policy.delay(attempt, header)
+				if !ok {
+					return resp, err
+				}
+				if err := sleepContext(ctx, d); err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+}
+
+// LoggingMiddleware пишет каждую попытку запроса в l; logBodies добавляет тела с маскированием
+func LoggingMiddleware(l Logger, logBodies bool) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			started := time.Now()
+			resp, err := next(ctx, req)
+			var reqBody []byte
+			if logBodies {
+				reqBody, _ = json.Marshal(req.Payload)
+			}
+			logAttempt(l, logBodies, req, reqBody, resp, time.Since(started), err)
+			return resp, err
+		}
+	}
+}
+
+// Metrics получатель метрик запросов для MetricsMiddleware
+type Metrics interface {
+	ObserveRequest(endpoint string, status int, duration time.Duration, err error)
+}
+
+// MetricsFunc адаптер функции к Metrics
+type MetricsFunc func(endpoint string, status int, duration time.Duration, err error)
+
+func (f MetricsFunc) ObserveRequest(endpoint string, status int, duration time.Duration, err error) {
+	f(endpoint, status, duration, err)
+}
+
+// MetricsMiddleware сообщает в m длительность и статус каждого вызова
+func MetricsMiddleware(m Metrics) Middleware {
+	return func(next Handler) Handler {
+		return func(ctx context.Context, req *Request) (*Response, error) {
+			started := time.Now()
+			resp, err := next(ctx, req)
+			status := 0
+			if resp != nil {
+				status = resp.StatusCode
+			}
+			m.ObserveRequest(req.Endpoint, status, time.Since(started), err)
+			return resp, err
+		}
+	}
+}
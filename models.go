@@ -4,6 +4,9 @@ import (
 	"encoding/json"
 )
 
+// TimeLayout формат дат iiko Cloud API (локальное время терминала)
+const TimeLayout = "2006-01-02 15:04:05.000"
+
 // BaseResponseModel соответствует полю correlationId во всех ответах
 type BaseResponseModel struct {
 	CorrelationID string `json:"correlationId,omitempty"`
@@ -16,10 +19,12 @@ type ErrorModel struct {
 	Error            string `json:"error,omitempty"`
 }
 
-// CustomErrorModel с добавлением HTTP статуса
+// CustomErrorModel с добавлением HTTP статуса, endpoint и сырого тела ответа
 type CustomErrorModel struct {
 	ErrorModel
-	StatusCode int `json:"-"`
+	StatusCode int    `json:"-"`
+	Endpoint   string `json:"-"`
+	Body       []byte `json:"-"`
 }
 
 // IdNameModel универсальная модель id+name
@@ -314,6 +319,9 @@ type ProductModel struct {
 	SeoText                 *string               `json:"seoText,omitempty"`
 	SeoKeywords             *string               `json:"seoKeywords,omitempty"`
 	SeoTitle                *string               `json:"seoTitle,omitempty"`
+	// Unavailable и UnavailableSizeIDs заполняет BaseStopListsModel.MarkNomenclature
+	Unavailable        bool     `json:"-"`
+	UnavailableSizeIDs []string `json:"-"`
 }
 
 type BaseNomenclatureModel struct {
@@ -369,6 +377,7 @@ type ItemModifierGroupItemModel struct {
 	PortionWeightGrams       float64              `json:"portionWeightGrams"`
 	Tags                     []IdNameModel        `json:"tags"`
 	ItemID                   string               `json:"itemId"`
+	Unavailable              bool                 `json:"-"`
 }
 
 type ItemModifierGroupModel struct {
@@ -394,6 +403,7 @@ type ItemSizeModel struct {
 	NutritionPerHundredGrams map[string]any           `json:"nutritionPerHundredGrams"`
 	ButtonImageURL           string                   `json:"buttonImageUrl"`
 	ButtonImageCroppedURL    string                   `json:"buttonImageCroppedUrl"`
+	Unavailable              bool                     `json:"-"`
 }
 
 type MenuItemModel struct {
@@ -406,6 +416,8 @@ type MenuItemModel struct {
 	TaxCategory      TaxCategoryModel     `json:"taxCategory"`
 	OrderItemType    string               `json:"orderItemType"`
 	ItemSizes        []ItemSizeModel      `json:"itemSizes"`
+	// Unavailable заполняет BaseStopListsModel.MarkMenu
+	Unavailable bool `json:"-"`
 }
 
 type MenuItemCategoryModel struct {
@@ -676,6 +688,248 @@ type BaseCreatedDeliveryOrderInfoModel struct {
 	OrderInfo *CreatedOrderInfoModel `json:"orderInfo,omitempty"`
 }
 
+// Order request models для /api/1/order/create и /api/1/deliveries/create
+type OrderItemModifierRequestModel struct {
+	ProductID      string   `json:"productId"`
+	Amount         float64  `json:"amount"`
+	ProductGroupID *string  `json:"productGroupId,omitempty"`
+	Price          *float64 `json:"price,omitempty"`
+	PositionID     *string  `json:"positionId,omitempty"`
+}
+
+type OrderItemComboInformationRequestModel struct {
+	ComboID       string `json:"comboId"`
+	ComboSourceID string `json:"comboSourceId"`
+	ComboGroupID  string `json:"comboGroupId"`
+}
+
+// OrderItemRequestModel позиция заказа. Для Product задается ProductID,
+// для Compound (пицца из половинок) — Template, PrimaryComponent и SecondaryComponent.
+type OrderItemRequestModel struct {
+	Type               string                                 `json:"type"`
+	ProductID          string                                 `json:"productId,omitempty"`
+	Amount             float64                                `json:"amount"`
+	ProductSizeID      *string                                `json:"productSizeId,omitempty"`
+	Modifiers          []OrderItemModifierRequestModel        `json:"modifiers,omitempty"`
+	Price              *float64                               `json:"price,omitempty"`
+	PositionID         *string                                `json:"positionId,omitempty"`
+	ComboInformation   *OrderItemComboInformationRequestModel `json:"comboInformation,omitempty"`
+	Comment            *string                                `json:"comment,omitempty"`
+	PrimaryComponent   *OrderItemComponentRequestModel        `json:"primaryComponent,omitempty"`
+	SecondaryComponent *OrderItemComponentRequestModel        `json:"secondaryComponent,omitempty"`
+	CommonModifiers    []OrderItemModifierRequestModel        `json:"commonModifiers,omitempty"`
+	Template           *string                                `json:"template,omitempty"`
+}
+
+// OrderItemComponentRequestModel половинка составной позиции (Compound)
+type OrderItemComponentRequestModel struct {
+	ProductID  string                          `json:"productId"`
+	Modifiers  []OrderItemModifierRequestModel `json:"modifiers,omitempty"`
+	Price      *float64                        `json:"price,omitempty"`
+	PositionID *string                         `json:"positionId,omitempty"`
+}
+
+type OrderComboRequestModel struct {
+	ID        string  `json:"id"`
+	Name      string  `json:"name"`
+	Amount    int     `json:"amount"`
+	Price     float64 `json:"price"`
+	SourceID  string  `json:"sourceId"`
+	ProgramID *string `json:"programId,omitempty"`
+	SizeID    *string `json:"sizeId,omitempty"`
+}
+
+type PaymentAdditionalDataRequestModel struct {
+	Type        string   `json:"type"`
+	Credential  *string  `json:"credential,omitempty"`
+	SearchScope *string  `json:"searchScope,omitempty"`
+	MinimalSum  *float64 `json:"minimalSum,omitempty"`
+}
+
+type OrderPaymentRequestModel struct {
+	PaymentTypeKind        string                             `json:"paymentTypeKind"`
+	Sum                    float64                            `json:"sum"`
+	PaymentTypeID          string                             `json:"paymentTypeId"`
+	IsProcessedExternally  *bool                              `json:"isProcessedExternally,omitempty"`
+	PaymentAdditionalData  *PaymentAdditionalDataRequestModel `json:"paymentAdditionalData,omitempty"`
+	IsFiscalizedExternally *bool                              `json:"isFiscalizedExternally,omitempty"`
+	IsPrepay               *bool                              `json:"isPrepay,omitempty"`
+}
+
+type OrderTipsRequestModel struct {
+	OrderPaymentRequestModel
+	TipsTypeID string `json:"tipsTypeId"`
+}
+
+type OrderDiscountRequestModel struct {
+	Type               string   `json:"type"`
+	DiscountTypeID     string   `json:"discountTypeId"`
+	Sum                *float64 `json:"sum,omitempty"`
+	SelectivePositions []string `json:"selectivePositions,omitempty"`
+}
+
+type DiscountCardRequestModel struct {
+	Track string `json:"track"`
+}
+
+type OrderDiscountsInfoRequestModel struct {
+	Card                  *DiscountCardRequestModel   `json:"card,omitempty"`
+	Discounts             []OrderDiscountRequestModel `json:"discounts,omitempty"`
+	FixedLoyaltyDiscounts *bool                       `json:"fixedLoyaltyDiscounts,omitempty"`
+}
+
+type CoordinatesModel struct {
+	Latitude  float64 `json:"latitude"`
+	Longitude float64 `json:"longitude"`
+}
+
+type DeliveryStreetRequestModel struct {
+	ClassifierID *string `json:"classifierId,omitempty"`
+	ID           *string `json:"id,omitempty"`
+	Name         *string `json:"name,omitempty"`
+	City         *string `json:"city,omitempty"`
+}
+
+type DeliveryAddressRequestModel struct {
+	Street    DeliveryStreetRequestModel `json:"street"`
+	Index     *string                    `json:"index,omitempty"`
+	House     string                     `json:"house"`
+	Building  *string                    `json:"building,omitempty"`
+	Flat      *string                    `json:"flat,omitempty"`
+	Entrance  *string                    `json:"entrance,omitempty"`
+	Floor     *string                    `json:"floor,omitempty"`
+	Doorphone *string                    `json:"doorphone,omitempty"`
+	RegionID  *string                    `json:"regionId,omitempty"`
+}
+
+type DeliveryPointRequestModel struct {
+	Coordinates           *CoordinatesModel            `json:"coordinates,omitempty"`
+	Address               *DeliveryAddressRequestModel `json:"address,omitempty"`
+	ExternalCartographyID *string                      `json:"externalCartographyId,omitempty"`
+	Comment               *string                      `json:"comment,omitempty"`
+}
+
+type OrderCustomerRequestModel struct {
+	ID                                    *string `json:"id,omitempty"`
+	Name                                  *string `json:"name,omitempty"`
+	Surname                               *string `json:"surname,omitempty"`
+	Comment                               *string `json:"comment,omitempty"`
+	Birthdate                             *string `json:"birthdate,omitempty"`
+	Email                                 *string `json:"email,omitempty"`
+	ShouldReceiveOrderStatusNotifications *bool   `json:"shouldReceiveOrderStatusNotifications,omitempty"`
+	Gender                                *string `json:"gender,omitempty"`
+	Type                                  string  `json:"type"`
+}
+
+type OrderGuestsRequestModel struct {
+	Count               int   `json:"count"`
+	SplitBetweenPersons *bool `json:"splitBetweenPersons,omitempty"`
+}
+
+// OrderRequestModel тело поля order для order/create и deliveries/create
+type OrderRequestModel struct {
+	ID                *string                         `json:"id,omitempty"`
+	ExternalNumber    *string                         `json:"externalNumber,omitempty"`
+	TableIDs          []string                        `json:"tableIds,omitempty"`
+	Customer          *OrderCustomerRequestModel      `json:"customer,omitempty"`
+	Phone             *string                         `json:"phone,omitempty"`
+	Guests            *OrderGuestsRequestModel        `json:"guests,omitempty"`
+	TabName           *string                         `json:"tabName,omitempty"`
+	MenuID            *string                         `json:"menuId,omitempty"`
+	OrderTypeID       *string                         `json:"orderTypeId,omitempty"`
+	OrderServiceType  *string                         `json:"orderServiceType,omitempty"`
+	CompleteBefore    *string                         `json:"completeBefore,omitempty"`
+	DeliveryPoint     *DeliveryPointRequestModel      `json:"deliveryPoint,omitempty"`
+	Comment           *string                         `json:"comment,omitempty"`
+	MarketingSourceID *string                         `json:"marketingSourceId,omitempty"`
+	Items             []OrderItemRequestModel         `json:"items"`
+	Combos            []OrderComboRequestModel        `json:"combos,omitempty"`
+	Payments          []OrderPaymentRequestModel      `json:"payments,omitempty"`
+	Tips              []OrderTipsRequestModel         `json:"tips,omitempty"`
+	SourceKey         *string                         `json:"sourceKey,omitempty"`
+	DiscountsInfo     *OrderDiscountsInfoRequestModel `json:"discountsInfo,omitempty"`
+	LoyaltyInfo       *LoyaltyInfoModel               `json:"loyaltyInfo,omitempty"`
+	ExternalData      []ExternalDataModel             `json:"externalData,omitempty"`
+}
+
+// ChequeAdditionalInfoModel дополнительные данные чека при закрытии заказа
+type ChequeAdditionalInfoModel struct {
+	NeedReceipt     bool    `json:"needReceipt"`
+	Email           *string `json:"email,omitempty"`
+	SettlementPlace *string `json:"settlementPlace,omitempty"`
+	Phone           *string `json:"phone,omitempty"`
+}
+
+// Table orders models для /api/1/order/by_table
+type TableOrderModel struct {
+	TableIDs             []string                  `json:"tableIds,omitempty"`
+	Customer             *CustomerModel            `json:"customer,omitempty"`
+	Phone                *string                   `json:"phone,omitempty"`
+	Status               string                    `json:"status"`
+	WhenCreated          string                    `json:"whenCreated"`
+	Waiter               *EmployeeModel            `json:"waiter,omitempty"`
+	TabName              *string                   `json:"tabName,omitempty"`
+	Sum                  float64                   `json:"sum"`
+	Number               int                       `json:"number"`
+	SourceKey            *string                   `json:"sourceKey,omitempty"`
+	WhenBillPrinted      *string                   `json:"whenBillPrinted,omitempty"`
+	WhenClosed           *string                   `json:"whenClosed,omitempty"`
+	Conception           *ConceptionOrderModel     `json:"conception,omitempty"`
+	GuestsInfo           GuestsInfoOrderModel      `json:"guestsInfo"`
+	Items                []OrderProductItemModel   `json:"items"`
+	Combos               []CombosItemOrderModel    `json:"combos,omitempty"`
+	Payments             []PaymentItemOrderModel   `json:"payments,omitempty"`
+	Tips                 []TipsItemOrderModel      `json:"tips,omitempty"`
+	Discounts            []DiscountsItemOrderModel `json:"discounts,omitempty"`
+	OrderType            *OrderTypeModel           `json:"orderType,omitempty"`
+	TerminalGroupID      string                    `json:"terminalGroupId"`
+	ProcessedPaymentsSum *float64                  `json:"processedPaymentsSum,omitempty"`
+}
+
+type TableOrderItemModel struct {
+	ID             string           `json:"id"`
+	PosID          *string          `json:"posId,omitempty"`
+	ExternalNumber *string          `json:"externalNumber,omitempty"`
+	OrganizationID string           `json:"organizationId"`
+	Timestamp      int64            `json:"timestamp"`
+	CreationStatus *string          `json:"creationStatus,omitempty"`
+	ErrorInfo      *ErrorInfoModel  `json:"errorInfo,omitempty"`
+	Order          *TableOrderModel `json:"order,omitempty"`
+}
+
+type ByTableModel struct {
+	BaseResponseModel
+	Orders []TableOrderItemModel `json:"orders,omitempty"`
+}
+
+// DeliveryCancelRequestModel параметры отмены доставки для /api/1/deliveries/cancel.
+// CancelCauseID и RemovalTypeID берутся из Dictionaries.CancelCauses и Dictionaries.RemovalTypes.
+type DeliveryCancelRequestModel struct {
+	MovedOrderID      *string `json:"movedOrderId,omitempty"`
+	CancelCauseID     *string `json:"cancelCauseId,omitempty"`
+	RemovalTypeID     *string `json:"removalTypeId,omitempty"`
+	UserIDForWriteoff *string `json:"userIdForWriteoff,omitempty"`
+}
+
+// Типы позиций и клиентов заказа
+const (
+	OrderItemTypeProduct  = "Product"
+	OrderItemTypeCompound = "Compound"
+
+	OrderCustomerTypeRegular = "regular"
+	OrderCustomerTypeOneTime = "one-time"
+
+	OrderServiceTypeDeliveryByCourier = "DeliveryByCourier"
+	OrderServiceTypeDeliveryByClient  = "DeliveryByClient"
+
+	PaymentTypeKindCash     = "Cash"
+	PaymentTypeKindCard     = "Card"
+	PaymentTypeKindIikoCard = "IikoCard"
+	PaymentTypeKindExternal = "External"
+
+	OrderDiscountTypeRMS = "RMS"
+)
+
 // Address/TerminalGroup models
 type RegionsItemModel struct {
 	ID               string `json:"id"`
@@ -955,3 +1209,730 @@ type BaseEmployeeTerminalModel struct {
 	EmployeeID string                      `json:"employeeId"`
 	Terminals  []EmployeeTerminalItemModel `json:"terminals"`
 }
+
+// Reserves models
+// Причины отмены резерва для /api/1/reserve/cancel
+const (
+	ReserveCancelReasonClientNotAppeared = "ClientNotAppeared"
+	ReserveCancelReasonClientRefused     = "ClientRefused"
+	ReserveCancelReasonOther             = "Other"
+)
+
+type ReserveOrganizationModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type BaseReserveOrganizationsModel struct {
+	BaseResponseModel
+	Organizations []ReserveOrganizationModel `json:"organizations"`
+}
+
+type RestaurantTableModel struct {
+	ID              string  `json:"id"`
+	Number          int     `json:"number"`
+	Name            string  `json:"name"`
+	SeatingCapacity int     `json:"seatingCapacity"`
+	Revision        int64   `json:"revision"`
+	IsDeleted       bool    `json:"isDeleted"`
+	PosID           *string `json:"posId,omitempty"`
+}
+
+type RestaurantSectionModel struct {
+	ID              string                 `json:"id"`
+	TerminalGroupID string                 `json:"terminalGroupId"`
+	Name            string                 `json:"name"`
+	Tables          []RestaurantTableModel `json:"tables"`
+	Schema          map[string]any         `json:"schema,omitempty"`
+}
+
+type BaseRestaurantSectionsModel struct {
+	BaseResponseModel
+	RestaurantSections []RestaurantSectionModel `json:"restaurantSections"`
+	Revision           int64                    `json:"revision"`
+}
+
+type SectionWorkloadReserveModel struct {
+	ID                 string   `json:"id"`
+	TableIDs           []string `json:"tableIds"`
+	EstimatedStartTime string   `json:"estimatedStartTime"`
+	DurationInMinutes  int      `json:"durationInMinutes"`
+	GuestsComingTime   *string  `json:"guestsComingTime,omitempty"`
+}
+
+type BaseSectionsWorkloadModel struct {
+	BaseResponseModel
+	Reserves []SectionWorkloadReserveModel `json:"reserves"`
+}
+
+// ReserveRequestModel резерв (банкет) для /api/1/reserve/create
+type ReserveRequestModel struct {
+	ID                 *string                    `json:"id,omitempty"`
+	ExternalNumber     *string                    `json:"externalNumber,omitempty"`
+	Order              *OrderRequestModel         `json:"order,omitempty"`
+	Customer           *OrderCustomerRequestModel `json:"customer,omitempty"`
+	Phone              string                     `json:"phone"`
+	GuestsCount        *int                       `json:"guestsCount,omitempty"`
+	Comment            *string                    `json:"comment,omitempty"`
+	DurationInMinutes  int                        `json:"durationInMinutes"`
+	ShouldRemind       bool                       `json:"shouldRemind"`
+	TableIDs           []string                   `json:"tableIds"`
+	EstimatedStartTime string                     `json:"estimatedStartTime"`
+	TransportNumber    *string                    `json:"transportNumber,omitempty"`
+	Guests             *OrderGuestsRequestModel   `json:"guests,omitempty"`
+}
+
+type ReserveModel struct {
+	Customer           *CustomerModel   `json:"customer,omitempty"`
+	Phone              *string          `json:"phone,omitempty"`
+	GuestsCount        *int             `json:"guestsCount,omitempty"`
+	Comment            *string          `json:"comment,omitempty"`
+	DurationInMinutes  int              `json:"durationInMinutes"`
+	ShouldRemind       bool             `json:"shouldRemind"`
+	Status             *string          `json:"status,omitempty"`
+	CancelInfo         *CancelInfoModel `json:"cancelInfo,omitempty"`
+	CancelReason       *string          `json:"cancelReason,omitempty"`
+	TableIDs           []string         `json:"tableIds,omitempty"`
+	EstimatedStartTime string           `json:"estimatedStartTime"`
+	GuestsComingTime   *string          `json:"guestsComingTime,omitempty"`
+	WhenCreated        *string          `json:"whenCreated,omitempty"`
+	Order              *TableOrderModel `json:"order,omitempty"`
+}
+
+type ReserveInfoModel struct {
+	ID             string          `json:"id"`
+	ExternalNumber *string         `json:"externalNumber,omitempty"`
+	OrganizationID string          `json:"organizationId"`
+	Timestamp      int64           `json:"timestamp"`
+	CreationStatus *string         `json:"creationStatus,omitempty"`
+	ErrorInfo      *ErrorInfoModel `json:"errorInfo,omitempty"`
+	IsDeleted      bool            `json:"isDeleted"`
+	Reserve        *ReserveModel   `json:"reserve,omitempty"`
+}
+
+type BaseCreatedReserveInfoModel struct {
+	BaseResponseModel
+	ReserveInfo *ReserveInfoModel `json:"reserveInfo,omitempty"`
+}
+
+type BaseReservesModel struct {
+	BaseResponseModel
+	Reserves []ReserveInfoModel `json:"reserves"`
+}
+
+type WebHookReserveEventInfoModel struct {
+	EventType      string            `json:"eventType"`
+	EventTime      *string           `json:"eventTime,omitempty"`
+	OrganizationID string            `json:"organizationId"`
+	CorrelationID  string            `json:"correlationId"`
+	EventInfo      *ReserveInfoModel `json:"eventInfo,omitempty"`
+}
+
+type WebHookTableOrderEventInfoModel struct {
+	EventType      string               `json:"eventType"`
+	EventTime      *string              `json:"eventTime,omitempty"`
+	OrganizationID string               `json:"organizationId"`
+	CorrelationID  string               `json:"correlationId"`
+	EventInfo      *TableOrderItemModel `json:"eventInfo,omitempty"`
+}
+
+type StopListUpdateItemModel struct {
+	// ID id терминальной группы
+	ID     string `json:"id"`
+	IsFull bool   `json:"isFull"`
+}
+
+type StopListUpdateEventInfoModel struct {
+	TerminalGroupsStopListsUpdates []StopListUpdateItemModel `json:"terminalGroupsStopListsUpdates"`
+}
+
+type WebHookStopListUpdateEventInfoModel struct {
+	EventType      string                        `json:"eventType"`
+	EventTime      *string                       `json:"eventTime,omitempty"`
+	OrganizationID string                        `json:"organizationId"`
+	CorrelationID  string                        `json:"correlationId"`
+	EventInfo      *StopListUpdateEventInfoModel `json:"eventInfo,omitempty"`
+}
+
+type PersonalShiftEventInfoModel struct {
+	// ID id сотрудника
+	ID              string  `json:"id"`
+	TerminalGroupID *string `json:"terminalGroupId,omitempty"`
+	RoleID          *string `json:"roleId,omitempty"`
+	Opened          bool    `json:"opened"`
+}
+
+type WebHookPersonalShiftEventInfoModel struct {
+	EventType      string                       `json:"eventType"`
+	EventTime      *string                      `json:"eventTime,omitempty"`
+	OrganizationID string                       `json:"organizationId"`
+	CorrelationID  string                       `json:"correlationId"`
+	EventInfo      *PersonalShiftEventInfoModel `json:"eventInfo,omitempty"`
+}
+
+type NomenclatureUpdateEventInfoModel struct {
+	Revision *int64 `json:"revision,omitempty"`
+}
+
+type WebHookNomenclatureUpdateEventInfoModel struct {
+	EventType      string                            `json:"eventType"`
+	EventTime      *string                           `json:"eventTime,omitempty"`
+	OrganizationID string                            `json:"organizationId"`
+	CorrelationID  string                            `json:"correlationId"`
+	EventInfo      *NomenclatureUpdateEventInfoModel `json:"eventInfo,omitempty"`
+}
+
+// Stop lists models
+type StopListItemModel struct {
+	Balance   float64 `json:"balance"`
+	ProductID string  `json:"productId"`
+	SizeID    *string `json:"sizeId,omitempty"`
+	SKU       *string `json:"sku,omitempty"`
+	DateAdd   *string `json:"dateAdd,omitempty"`
+}
+
+type TerminalGroupStopListModel struct {
+	TerminalGroupID string              `json:"terminalGroupId"`
+	Items           []StopListItemModel `json:"items"`
+}
+
+type OrganizationStopListModel struct {
+	OrganizationID string                       `json:"organizationId"`
+	Items          []TerminalGroupStopListModel `json:"items"`
+}
+
+type BaseStopListsModel struct {
+	BaseResponseModel
+	TerminalGroupStopLists []OrganizationStopListModel `json:"terminalGroupStopLists"`
+}
+
+type BaseStopListCheckModel struct {
+	BaseResponseModel
+	RejectedItems []StopListItemModel `json:"rejectedItems"`
+}
+
+// StopListAddItemModel позиция для /api/1/stop_lists/add
+type StopListAddItemModel struct {
+	ProductID string  `json:"productId"`
+	SizeID    *string `json:"sizeId,omitempty"`
+	Balance   float64 `json:"balance"`
+}
+
+// StopListRemoveItemModel позиция для /api/1/stop_lists/remove
+type StopListRemoveItemModel struct {
+	ProductID string  `json:"productId"`
+	SizeID    *string `json:"sizeId,omitempty"`
+}
+
+// Loyalty models для /api/1/loyalty/iiko/*
+// LoyaltyCalculateRequestModel дополнительные параметры расчета программ лояльности
+type LoyaltyCalculateRequestModel struct {
+	Coupon                               *string  `json:"coupon,omitempty"`
+	ReferrerID                           *string  `json:"referrerId,omitempty"`
+	TerminalGroupID                      *string  `json:"terminalGroupId,omitempty"`
+	AvailablePaymentMarketingCampaignIDs []string `json:"availablePaymentMarketingCampaignIds,omitempty"`
+	ApplicableManualConditions           []string `json:"applicableManualConditions,omitempty"`
+	IsLoyaltyTraceEnabled                *bool    `json:"isLoyaltyTraceEnabled,omitempty"`
+}
+
+type LoyaltyDiscountModel struct {
+	Code        int     `json:"code"`
+	OrderItemID *string `json:"orderItemId,omitempty"`
+	PositionID  *string `json:"positionId,omitempty"`
+	DiscountSum float64 `json:"discountSum"`
+	Amount      float64 `json:"amount"`
+	Comment     *string `json:"comment,omitempty"`
+}
+
+type LoyaltyUpsaleModel struct {
+	SourceActionID string   `json:"sourceActionId"`
+	SuggestionText *string  `json:"suggestionText,omitempty"`
+	ProductCodes   []string `json:"productCodes,omitempty"`
+}
+
+type LoyaltyFreeProductModel struct {
+	ID   string   `json:"id"`
+	Code *string  `json:"code,omitempty"`
+	Size []string `json:"size,omitempty"`
+}
+
+type LoyaltyFreeProductsModel struct {
+	SourceActionID     string                    `json:"sourceActionId"`
+	DescriptionForUser *string                   `json:"descriptionForUser,omitempty"`
+	Products           []LoyaltyFreeProductModel `json:"products"`
+}
+
+type LoyaltyProgramResultModel struct {
+	MarketingCampaignID       string                     `json:"marketingCampaignId"`
+	Name                      string                     `json:"name"`
+	Discounts                 []LoyaltyDiscountModel     `json:"discounts,omitempty"`
+	Upsales                   []LoyaltyUpsaleModel       `json:"upsales,omitempty"`
+	FreeProducts              []LoyaltyFreeProductsModel `json:"freeProducts,omitempty"`
+	AvailableComment          *string                    `json:"availableComment,omitempty"`
+	NeedToActivateCertificate bool                       `json:"needToActivateCertificate"`
+}
+
+type LoyaltyWalletInfoModel struct {
+	ID           string  `json:"id"`
+	MaxSum       float64 `json:"maxSum"`
+	CanHoldMoney bool    `json:"canHoldMoney"`
+}
+
+type LoyaltyAvailablePaymentModel struct {
+	ID          string                   `json:"id"`
+	MaxSum      float64                  `json:"maxSum"`
+	Order       int                      `json:"order"`
+	WalletInfos []LoyaltyWalletInfoModel `json:"walletInfos,omitempty"`
+}
+
+type LoyaltyWarningModel struct {
+	Code      *string `json:"code,omitempty"`
+	ErrorCode *string `json:"errorCode,omitempty"`
+	Message   *string `json:"message,omitempty"`
+}
+
+type LoyaltyCalculateModel struct {
+	LoyaltyProgramResults []LoyaltyProgramResultModel    `json:"loyaltyProgramResults"`
+	AvailablePayments     []LoyaltyAvailablePaymentModel `json:"availablePayments,omitempty"`
+	ValidationWarnings    []LoyaltyWarningModel          `json:"validationWarnings,omitempty"`
+	Warnings              []LoyaltyWarningModel          `json:"Warnings,omitempty"`
+	LoyaltyTrace          *string                        `json:"loyaltyTrace,omitempty"`
+}
+
+type LoyaltyMarketingCampaignModel struct {
+	ID          string  `json:"id"`
+	ProgramID   *string `json:"programId,omitempty"`
+	Name        string  `json:"name"`
+	Description *string `json:"description,omitempty"`
+	IsActive    bool    `json:"isActive"`
+	PeriodFrom  *string `json:"periodFrom,omitempty"`
+	PeriodTo    *string `json:"periodTo,omitempty"`
+}
+
+type LoyaltyProgramModel struct {
+	ID                                     string                          `json:"id"`
+	Name                                   string                          `json:"name"`
+	Description                            *string                         `json:"description,omitempty"`
+	ServiceFrom                            *string                         `json:"serviceFrom,omitempty"`
+	ServiceTo                              *string                         `json:"serviceTo,omitempty"`
+	NotificationAboutBalanceChangesSendsTo int                             `json:"notificationAboutBalanceChangesSendsTo"`
+	RefillType                             int                             `json:"refillType"`
+	ProgramType                            int                             `json:"programType"`
+	IsActive                               bool                            `json:"isActive"`
+	WalletID                               string                          `json:"walletId"`
+	MarketingCampaigns                     []LoyaltyMarketingCampaignModel `json:"marketingCampaigns,omitempty"`
+	AppliedOrganizations                   []string                        `json:"appliedOrganizations,omitempty"`
+	HasWelcomeBonus                        bool                            `json:"hasWelcomeBonus"`
+	WelcomeBonusSum                        *float64                        `json:"welcomeBonusSum,omitempty"`
+}
+
+type LoyaltyProgramsModel struct {
+	Programs []LoyaltyProgramModel `json:"Programs"`
+}
+
+type CouponInfoModel struct {
+	ID            string  `json:"id"`
+	Number        string  `json:"number"`
+	SeriesName    *string `json:"seriesName,omitempty"`
+	SeriesID      *string `json:"seriesId,omitempty"`
+	WhenActivated *string `json:"whenActivated,omitempty"`
+	IsDeleted     bool    `json:"isDeleted"`
+}
+
+type CouponsInfoModel struct {
+	CouponInfo []CouponInfoModel `json:"couponInfo"`
+}
+
+type CouponSeriesModel struct {
+	Series                  string `json:"series"`
+	SeriesID                string `json:"seriesId"`
+	CountNotActivatedCoupon int    `json:"countNotActivatedCoupon"`
+}
+
+type CouponSeriesListModel struct {
+	SeriesWithNotActivatedCoupon []CouponSeriesModel `json:"seriesWithNotActivatedCoupon"`
+}
+
+type ManualConditionModel struct {
+	ID   string  `json:"id"`
+	Name string  `json:"name"`
+	Code *string `json:"code,omitempty"`
+}
+
+type ManualConditionsModel struct {
+	ManualConditions []ManualConditionModel `json:"manualConditions"`
+}
+
+// Периоды счетчиков гостя для /api/1/loyalty/iiko/get_counters
+const (
+	CounterPeriodAllTime = iota
+	CounterPeriodDay
+	CounterPeriodWeek
+	CounterPeriodMonth
+	CounterPeriodQuarter
+	CounterPeriodYear
+)
+
+// Метрики счетчиков гостя для /api/1/loyalty/iiko/get_counters
+const (
+	CounterMetricOrdersCount = iota
+	CounterMetricOrdersSum
+	CounterMetricDishesCount
+	CounterMetricDishesSum
+)
+
+type GuestCounterModel struct {
+	Metric int     `json:"metric"`
+	Period int     `json:"period"`
+	Value  float64 `json:"value"`
+}
+
+type GuestCountersItemModel struct {
+	GuestID  string              `json:"guestId"`
+	Counters []GuestCounterModel `json:"counters"`
+}
+
+type GuestCountersModel struct {
+	GuestCounters []GuestCountersItemModel `json:"guestCounters"`
+}
+
+// Customer categories and transactions models
+type CustomerCategoriesModel struct {
+	GuestCategories []CategoriesCIModel `json:"guestCategories"`
+}
+
+// CustomerTransactionType код типа транзакции кошелька гостя (TransactionType iikoCard)
+type CustomerTransactionType int
+
+// Типы транзакций кошелька гостя
+const (
+	// CustomerTransactionTypeRefillWallet пополнение кошелька (wallet/topup)
+	CustomerTransactionTypeRefillWallet CustomerTransactionType = 1
+	// CustomerTransactionTypePayFromWallet списание с кошелька (wallet/chargeoff, оплата заказа)
+	CustomerTransactionTypePayFromWallet CustomerTransactionType = 2
+	// CustomerTransactionTypeHoldMoney холдирование средств (wallet/hold)
+	CustomerTransactionTypeHoldMoney CustomerTransactionType = 3
+	// CustomerTransactionTypeCancelHoldMoney отмена холда
+	CustomerTransactionTypeCancelHoldMoney CustomerTransactionType = 4
+)
+
+type CustomerTransactionModel struct {
+	ID                    string                  `json:"id"`
+	Revision              int64                   `json:"revision"`
+	Type                  CustomerTransactionType `json:"type"`
+	TypeName              *string                 `json:"typeName,omitempty"`
+	Sum                   float64                 `json:"sum"`
+	BalanceBefore         *float64                `json:"balanceBefore,omitempty"`
+	BalanceAfter          *float64                `json:"balanceAfter,omitempty"`
+	Comment               *string                 `json:"comment,omitempty"`
+	WhenCreated           string                  `json:"whenCreated"`
+	ApiClientLogin        *string                 `json:"apiClientLogin,omitempty"`
+	ProgramID             *string                 `json:"programId,omitempty"`
+	ProgramName           *string                 `json:"programName,omitempty"`
+	MarketingCampaignID   *string                 `json:"marketingCampaignId,omitempty"`
+	MarketingCampaignName *string                 `json:"marketingCampaignName,omitempty"`
+	OrderID               *string                 `json:"orderId,omitempty"`
+	OrderNumber           *int                    `json:"orderNumber,omitempty"`
+	OrderSum              *float64                `json:"orderSum,omitempty"`
+	PosOrderID            *string                 `json:"posOrderId,omitempty"`
+	OrganizationID        *string                 `json:"organizationId,omitempty"`
+	OrganizationName      *string                 `json:"organizationName,omitempty"`
+	TerminalGroupID       *string                 `json:"terminalGroupId,omitempty"`
+	TerminalGroupName     *string                 `json:"terminalGroupName,omitempty"`
+	IsIgnored             bool                    `json:"isIgnored"`
+	IsDelivery            bool                    `json:"isDelivery"`
+}
+
+type CustomerTransactionsModel struct {
+	Transactions []CustomerTransactionModel `json:"transactions"`
+	// LastRevision ревизия последней транзакции (только by_revision)
+	LastRevision int64 `json:"lastRevision"`
+}
+
+// Статусы согласия гостя на рассылки (CustomerInfoModel.ConsentStatus)
+const (
+	ConsentStatusUnknown = 0
+	ConsentStatusGiven   = 1
+	ConsentStatusRevoked = 2
+)
+
+// Каналы и статусы сообщений iikoCard
+const (
+	MessageChannelSMS   = "sms"
+	MessageChannelEmail = "email"
+
+	MessageStatusAccepted = "Accepted"
+	MessageStatusRefused  = "Refused"
+)
+
+// MessageRecipient получатель сообщения. Phone и Email по умолчанию берутся из Customer.
+// RequireConsent включает проверку согласия на рекламные рассылки по Customer (CanReceivePromo);
+// транзакционные сообщения (статус заказа, коды подтверждения) отправляются без нее.
+type MessageRecipient struct {
+	Customer       *CustomerInfoModel
+	Phone          string
+	Email          string
+	RequireConsent bool
+}
+
+// MessageStatusModel результат отправки сообщения
+type MessageStatusModel struct {
+	Channel   string
+	Recipient string
+	// Status MessageStatusAccepted — iikoCard принял сообщение к отправке, MessageStatusRefused — отказ по согласию
+	Status        string
+	CorrelationID string
+}
+
+// Combos models для /api/1/combo
+type ComboProductModel struct {
+	ProductID                string         `json:"productId"`
+	SizeID                   *string        `json:"sizeId,omitempty"`
+	ForbiddenModifiers       []string       `json:"forbiddenModifiers,omitempty"`
+	PriceModificationAmounts map[string]any `json:"priceModificationAmounts,omitempty"`
+}
+
+type ComboGroupModel struct {
+	ID          string              `json:"id"`
+	Name        string              `json:"name"`
+	IsMainGroup bool                `json:"isMainGroup"`
+	Products    []ComboProductModel `json:"products"`
+}
+
+type ComboSpecificationModel struct {
+	SourceActionID         string            `json:"sourceActionId"`
+	CategoryID             *string           `json:"categoryId,omitempty"`
+	Name                   string            `json:"name"`
+	PriceModificationType  int               `json:"priceModificationType"`
+	PriceModification      float64           `json:"priceModification"`
+	IsActive               bool              `json:"isActive"`
+	StartDate              *string           `json:"startDate,omitempty"`
+	ExpirationDate         *string           `json:"expirationDate,omitempty"`
+	LackingGroupsToSuggest int               `json:"lackingGroupsToSuggest"`
+	IncludeModifiers       bool              `json:"includeModifiers"`
+	Groups                 []ComboGroupModel `json:"groups"`
+}
+
+type ComboCategoryModel struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+type BaseCombosModel struct {
+	ComboSpecifications []ComboSpecificationModel `json:"comboSpecifications"`
+	ComboCategories     []ComboCategoryModel      `json:"comboCategories"`
+	Warnings            []LoyaltyWarningModel     `json:"Warnings,omitempty"`
+}
+
+// ComboPriceItemRequestModel продукт группы комбо для /api/1/combo/get_combo_price
+type ComboPriceItemRequestModel struct {
+	ProductID string  `json:"productId"`
+	SizeID    *string `json:"sizeId,omitempty"`
+	GroupID   string  `json:"groupId"`
+}
+
+type ComboPriceModel struct {
+	Price                   float64  `json:"price"`
+	IncorrectlyFilledGroups []string `json:"incorrectlyFilledGroups,omitempty"`
+}
+
+// Delivery restrictions models для /api/1/delivery_restrictions
+type DeliveryZoneCircleModel struct {
+	Center CoordinatesModel `json:"center"`
+	// Radius радиус в километрах
+	Radius float64 `json:"radius"`
+}
+
+type DeliveryZoneModel struct {
+	Name        string                    `json:"name"`
+	Coordinates []CoordinatesModel        `json:"coordinates,omitempty"`
+	Circles     []DeliveryZoneCircleModel `json:"circles,omitempty"`
+	Polygons    [][]CoordinatesModel      `json:"polygons,omitempty"`
+}
+
+type DeliveryRestrictionItemModel struct {
+	MinSum                    *float64 `json:"minSum,omitempty"`
+	TerminalGroupID           string   `json:"terminalGroupId"`
+	OrganizationID            string   `json:"organizationId"`
+	Zone                      string   `json:"zone"`
+	WeekMap                   int      `json:"weekMap"`
+	From                      *int     `json:"from,omitempty"`
+	To                        *int     `json:"to,omitempty"`
+	Priority                  int      `json:"priority"`
+	DeliveryDurationInMinutes int      `json:"deliveryDurationInMinutes"`
+	DeliveryServiceProductID  *string  `json:"deliveryServiceProductId,omitempty"`
+}
+
+type DeliveryRestrictionModel struct {
+	OrganizationID                      string                         `json:"organizationId"`
+	DeliveryGeocodeServiceType          int                            `json:"deliveryGeocodeServiceType"`
+	DeliveryRegionsMapURL               *string                        `json:"deliveryRegionsMapUrl,omitempty"`
+	DefaultDeliveryDurationInMinutes    int                            `json:"defaultDeliveryDurationInMinutes"`
+	DefaultSelfServiceDurationInMinutes int                            `json:"defaultSelfServiceDurationInMinutes"`
+	UseSameDeliveryDuration             bool                           `json:"useSameDeliveryDuration"`
+	UseSameMinSum                       bool                           `json:"useSameMinSum"`
+	DefaultMinSum                       *float64                       `json:"defaultMinSum,omitempty"`
+	UseSameWorkTimeInterval             bool                           `json:"useSameWorkTimeInterval"`
+	DefaultFrom                         *int                           `json:"defaultFrom,omitempty"`
+	DefaultTo                           *int                           `json:"defaultTo,omitempty"`
+	UseSameRestrictionsOnAllWeek        bool                           `json:"useSameRestrictionsOnAllWeek"`
+	Restrictions                        []DeliveryRestrictionItemModel `json:"restrictions"`
+	DeliveryZones                       []DeliveryZoneModel            `json:"deliveryZones"`
+	RejectOnGeocodingError              bool                           `json:"rejectOnGeocodingError"`
+	AddDeliveryServiceCost              bool                           `json:"addDeliveryServiceCost"`
+	UseSameDeliveryServiceProduct       bool                           `json:"useSameDeliveryServiceProduct"`
+	DefaultDeliveryServiceProductID     *string                        `json:"defaultDeliveryServiceProductId,omitempty"`
+	UseExternalAssignationService       bool                           `json:"useExternalAssignationService"`
+	FrontTrustsCallCenterCheck          bool                           `json:"frontTrustsCallCenterCheck"`
+	ExternalAssignationServiceURL       *string                        `json:"externalAssignationServiceUrl,omitempty"`
+	RequireExactAddressForGeocoding     bool                           `json:"requireExactAddressForGeocoding"`
+	ZonesMode                           int                            `json:"zonesMode"`
+	AutoAssignExternalDeliveries        bool                           `json:"autoAssignExternalDeliveries"`
+	ActionOnValidationRejection         int                            `json:"actionOnValidationRejection"`
+}
+
+type BaseDeliveryRestrictionsModel struct {
+	BaseResponseModel
+	DeliveryRestrictions []DeliveryRestrictionModel `json:"deliveryRestrictions"`
+}
+
+// DeliveryRestrictionAddressModel адрес для /api/1/delivery_restrictions/allowed (см. NewRestrictionAddress)
+type DeliveryRestrictionAddressModel struct {
+	City       string  `json:"city"`
+	StreetName string  `json:"streetName"`
+	StreetID   *string `json:"streetId,omitempty"`
+	House      string  `json:"house"`
+	Building   *string `json:"building,omitempty"`
+	Index      *string `json:"index,omitempty"`
+}
+
+type DeliveryAllowedItemRequestModel struct {
+	ProductID string  `json:"productId"`
+	Price     float64 `json:"price"`
+	Amount    float64 `json:"amount"`
+}
+
+// DeliveryAllowedRequestModel параметры проверки доставки: адрес или координаты, сумма и время
+type DeliveryAllowedRequestModel struct {
+	DeliveryAddress   *DeliveryRestrictionAddressModel  `json:"deliveryAddress,omitempty"`
+	OrderLocation     *CoordinatesModel                 `json:"orderLocation,omitempty"`
+	OrderItems        []DeliveryAllowedItemRequestModel `json:"orderItems,omitempty"`
+	IsCourierDelivery bool                              `json:"isCourierDelivery"`
+	DeliveryDate      *string                           `json:"deliveryDate,omitempty"`
+	DeliverySum       *float64                          `json:"deliverySum,omitempty"`
+	DiscountSum       *float64                          `json:"discountSum,omitempty"`
+}
+
+type DeliveryAllowedItemModel struct {
+	TerminalGroupID           string  `json:"terminalGroupId"`
+	OrganizationID            string  `json:"organizationId"`
+	DeliveryDurationInMinutes int     `json:"deliveryDurationInMinutes"`
+	Zone                      *string `json:"zone,omitempty"`
+	DeliveryServiceProductID  *string `json:"deliveryServiceProductId,omitempty"`
+}
+
+type DeliveryRejectedItemModel struct {
+	TerminalGroupID string   `json:"terminalGroupId"`
+	OrganizationID  string   `json:"organizationId"`
+	Zone            *string  `json:"zone,omitempty"`
+	RejectCode      *string  `json:"rejectCode,omitempty"`
+	RejectReason    *string  `json:"rejectReason,omitempty"`
+	MinSum          *float64 `json:"minSum,omitempty"`
+	WorkTimeLimit   *string  `json:"workTimeLimit,omitempty"`
+}
+
+type DeliveryAllowedModel struct {
+	BaseResponseModel
+	IsAllowed         bool                        `json:"isAllowed"`
+	Message           *string                     `json:"message,omitempty"`
+	AllowedItems      []DeliveryAllowedItemModel  `json:"allowedItems,omitempty"`
+	RejectedItems     []DeliveryRejectedItemModel `json:"rejectedItems,omitempty"`
+	AddressExternalID *string                     `json:"addressExternalId,omitempty"`
+	Location          *CoordinatesModel           `json:"location,omitempty"`
+}
+
+// Courier locations models
+type CourierLocationPointModel struct {
+	Latitude        float64 `json:"latitude"`
+	Longitude       float64 `json:"longitude"`
+	ServerTimestamp *string `json:"serverTimestamp,omitempty"`
+	ClientTimestamp *string `json:"clientTimestamp,omitempty"`
+}
+
+type ActiveCourierLocationModel struct {
+	CourierID          string                    `json:"courierId"`
+	LastActiveLocation CourierLocationPointModel `json:"lastActiveLocation"`
+}
+
+type ActiveCourierLocationsByOrganizationModel struct {
+	OrganizationID string                       `json:"organizationId"`
+	Items          []ActiveCourierLocationModel `json:"items"`
+}
+
+type BaseActiveCourierLocationsModel struct {
+	BaseResponseModel
+	ActiveCourierLocations []ActiveCourierLocationsByOrganizationModel `json:"activeCourierLocations"`
+}
+
+type CourierLocationsModel struct {
+	CourierID string                      `json:"courierId"`
+	Locations []CourierLocationPointModel `json:"locations"`
+}
+
+type CourierLocationsByOrganizationModel struct {
+	OrganizationID string                  `json:"organizationId"`
+	Items          []CourierLocationsModel `json:"items"`
+}
+
+type BaseCourierLocationsModel struct {
+	BaseResponseModel
+	CourierLocations []CourierLocationsByOrganizationModel `json:"courierLocations"`
+}
+
+// WebHook settings models для /api/1/webhooks/settings
+type WebHookOrderFilterModel struct {
+	OrderStatuses []string `json:"orderStatuses,omitempty"`
+	ItemStatuses  []string `json:"itemStatuses,omitempty"`
+	Errors        bool     `json:"errors"`
+}
+
+type WebHookUpdatesFilterModel struct {
+	Updates bool `json:"updates"`
+}
+
+type WebHookReserveFilterModel struct {
+	Updates bool `json:"updates"`
+	Errors  bool `json:"errors"`
+}
+
+// WebHookFilterModel фильтр событий: nil-фильтр отключает события своего типа
+type WebHookFilterModel struct {
+	DeliveryOrderFilter  *WebHookOrderFilterModel   `json:"deliveryOrderFilter,omitempty"`
+	TableOrderFilter     *WebHookOrderFilterModel   `json:"tableOrderFilter,omitempty"`
+	ReserveFilter        *WebHookReserveFilterModel `json:"reserveFilter,omitempty"`
+	StopListUpdateFilter *WebHookUpdatesFilterModel `json:"stopListUpdateFilter,omitempty"`
+	PersonalShiftFilter  *WebHookUpdatesFilterModel `json:"personalShiftFilter,omitempty"`
+}
+
+// WebHookSettingsRequestModel настройки webhook организации для /api/1/webhooks/update_settings
+type WebHookSettingsRequestModel struct {
+	WebHooksURI    string              `json:"webHooksUri"`
+	AuthToken      *string             `json:"authToken,omitempty"`
+	WebHooksFilter *WebHookFilterModel `json:"webHooksFilter,omitempty"`
+}
+
+type WebHookSettingsModel struct {
+	BaseResponseModel
+	APILoginName   *string             `json:"apiLoginName,omitempty"`
+	WebHooksURI    *string             `json:"webHooksUri,omitempty"`
+	AuthToken      *string             `json:"authToken,omitempty"`
+	WebHooksFilter *WebHookFilterModel `json:"webHooksFilter,omitempty"`
+}
+
+// WebHookEnsureResult результат EnsureSettings для одной организации
+type WebHookEnsureResult struct {
+	OrganizationID string
+	// Updated настройки отличались и были обновлены
+	Updated bool
+	Err     error
+}
@@ -24,10 +24,11 @@ func (n *Notifications) Send(ctx context.Context, orderSource, orderID, addition
 	}
 	body, status, err := n.client.post(ctx, "/api/1/notifications/send", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/notifications/send", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseResponseModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := n.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
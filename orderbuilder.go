@@ -0,0 +1,327 @@
+package goiikoapi
+
+import (
+	"errors"
+	"fmt"
+	"time"
+)
+
+// OrderItemOption опции позиции заказа для OrderBuilder.AddProduct
+type OrderItemOption func(*OrderItemRequestModel)
+
+func WithItemSize(sizeID string) OrderItemOption {
+	return func(item *OrderItemRequestModel) { item.ProductSizeID = &sizeID }
+}
+func WithItemModifier(productID string, amount float64, productGroupID *string) OrderItemOption {
+	return func(item *OrderItemRequestModel) {
+		item.Modifiers = append(item.Modifiers, OrderItemModifierRequestModel{
+			ProductID:      productID,
+			Amount:         amount,
+			ProductGroupID: productGroupID,
+		})
+	}
+}
+func WithItemPrice(price float64) OrderItemOption {
+	return func(item *OrderItemRequestModel) { item.Price = &price }
+}
+func WithItemPositionID(positionID string) OrderItemOption {
+	return func(item *OrderItemRequestModel) { item.PositionID = &positionID }
+}
+func WithItemComment(comment string) OrderItemOption {
+	return func(item *OrderItemRequestModel) { item.Comment = &comment }
+}
+func WithItemCombo(comboID, comboSourceID, comboGroupID string) OrderItemOption {
+	return func(item *OrderItemRequestModel) {
+		item.ComboInformation = &OrderItemComboInformationRequestModel{
+			ComboID:       comboID,
+			ComboSourceID: comboSourceID,
+			ComboGroupID:  comboGroupID,
+		}
+	}
+}
+
+// OrderBuilder собирает OrderRequestModel для OrderCreateTyped/DeliveryCreateTyped
+type OrderBuilder struct {
+	order OrderRequestModel
+}
+
+// NewOrderBuilder создает пустой построитель заказа
+func NewOrderBuilder() *OrderBuilder {
+	return &OrderBuilder{}
+}
+
+func (b *OrderBuilder) ID(id string) *OrderBuilder {
+	b.order.ID = &id
+	return b
+}
+
+func (b *OrderBuilder) ExternalNumber(number string) *OrderBuilder {
+	b.order.ExternalNumber = &number
+	return b
+}
+
+func (b *OrderBuilder) Tables(tableIDs ...string) *OrderBuilder {
+	b.order.TableIDs = append(b.order.TableIDs, tableIDs...)
+	return b
+}
+
+func (b *OrderBuilder) Phone(phone string) *OrderBuilder {
+	b.order.Phone = &phone
+	return b
+}
+
+func (b *OrderBuilder) Customer(customer OrderCustomerRequestModel) *OrderBuilder {
+	if customer.Type == "" {
+		customer.Type = OrderCustomerTypeRegular
+	}
+	b.order.Customer = &customer
+	return b
+}
+
+func (b *OrderBuilder) Guests(count int, splitBetweenPersons bool) *OrderBuilder {
+	b.order.Guests = &OrderGuestsRequestModel{Count: count, SplitBetweenPersons: &splitBetweenPersons}
+	return b
+}
+
+func (b *OrderBuilder) TabName(name string) *OrderBuilder {
+	b.order.TabName = &name
+	return b
+}
+
+func (b *OrderBuilder) MenuID(menuID string) *OrderBuilder {
+	b.order.MenuID = &menuID
+	return b
+}
+
+func (b *OrderBuilder) OrderType(orderTypeID string) *OrderBuilder {
+	b.order.OrderTypeID = &orderTypeID
+	return b
+}
+
+func (b *OrderBuilder) ServiceType(orderServiceType string) *OrderBuilder {
+	b.order.OrderServiceType = &orderServiceType
+	return b
+}
+
+func (b *OrderBuilder) CompleteBefore(t time.Time) *OrderBuilder {
+	s := t.Format(TimeLayout)
+	b.order.CompleteBefore = &s
+	return b
+}
+
+func (b *OrderBuilder) DeliveryPoint(point DeliveryPointRequestModel) *OrderBuilder {
+	b.order.DeliveryPoint = &point
+	return b
+}
+
+func (b *OrderBuilder) Comment(comment string) *OrderBuilder {
+	b.order.Comment = &comment
+	return b
+}
+
+func (b *OrderBuilder) MarketingSource(marketingSourceID string) *OrderBuilder {
+	b.order.MarketingSourceID = &marketingSourceID
+	return b
+}
+
+func (b *OrderBuilder) SourceKey(sourceKey string) *OrderBuilder {
+	b.order.SourceKey = &sourceKey
+	return b
+}
+
+// AddProduct добавляет позицию типа Product
+func (b *OrderBuilder) AddProduct(productID string, amount float64, opts ...OrderItemOption) *OrderBuilder {
+	item := OrderItemRequestModel{Type: OrderItemTypeProduct, ProductID: productID, Amount: amount}
+	for _, opt := range opts {
+		opt(&item)
+	}
+	b.order.Items = append(b.order.Items, item)
+	return b
+}
+
+// AddCompound добавляет составную позицию (Compound) по шаблону templateID;
+// secondary == nil — позиция из одного компонента
+func (b *OrderBuilder) AddCompound(templateID string, amount float64, primary OrderItemComponentRequestModel, secondary *OrderItemComponentRequestModel, opts ...OrderItemOption) *OrderBuilder {
+	item := OrderItemRequestModel{
+		Type:               OrderItemTypeCompound,
+		Amount:             amount,
+		Template:           &templateID,
+		PrimaryComponent:   &primary,
+		SecondaryComponent: secondary,
+	}
+	for _, opt := range opts {
+		opt(&item)
+	}
+	// модификаторы из опций относятся ко всей позиции
+	item.CommonModifiers, item.Modifiers = append(item.CommonModifiers, item.Modifiers...), nil
+	b.order.Items = append(b.order.Items, item)
+	return b
+}
+
+// AddItem добавляет заранее собранную позицию
+func (b *OrderBuilder) AddItem(item OrderItemRequestModel) *OrderBuilder {
+	if item.Type == "" {
+		item.Type = OrderItemTypeProduct
+	}
+	b.order.Items = append(b.order.Items, item)
+	return b
+}
+
+func (b *OrderBuilder) AddCombo(combo OrderComboRequestModel) *OrderBuilder {
+	b.order.Combos = append(b.order.Combos, combo)
+	return b
+}
+
+// AddPayment добавляет оплату с указанным видом (PaymentTypeKind*) и типом оплаты
+func (b *OrderBuilder) AddPayment(paymentTypeKind, paymentTypeID string, sum float64, isProcessedExternally bool) *OrderBuilder {
+	b.order.Payments = append(b.order.Payments, OrderPaymentRequestModel{
+		PaymentTypeKind:       paymentTypeKind,
+		PaymentTypeID:         paymentTypeID,
+		Sum:                   sum,
+		IsProcessedExternally: &isProcessedExternally,
+	})
+	return b
+}
+
+// AddPaymentModel добавляет заранее собранную оплату
+func (b *OrderBuilder) AddPaymentModel(payment OrderPaymentRequestModel) *OrderBuilder {
+	b.order.Payments = append(b.order.Payments, payment)
+	return b
+}
+
+func (b *OrderBuilder) AddTips(tipsTypeID, paymentTypeKind, paymentTypeID string, sum float64) *OrderBuilder {
+	b.order.Tips = append(b.order.Tips, OrderTipsRequestModel{
+		OrderPaymentRequestModel: OrderPaymentRequestModel{
+			PaymentTypeKind: paymentTypeKind,
+			PaymentTypeID:   paymentTypeID,
+			Sum:             sum,
+		},
+		TipsTypeID: tipsTypeID,
+	})
+	return b
+}
+
+// AddDiscount добавляет скидку RMS. sum == nil означает расчет скидки на стороне iiko
+func (b *OrderBuilder) AddDiscount(discountTypeID string, sum *float64, selectivePositions ...string) *OrderBuilder {
+	if b.order.DiscountsInfo == nil {
+		b.order.DiscountsInfo = &OrderDiscountsInfoRequestModel{}
+	}
+	b.order.DiscountsInfo.Discounts = append(b.order.DiscountsInfo.Discounts, OrderDiscountRequestModel{
+		Type:               OrderDiscountTypeRMS,
+		DiscountTypeID:     discountTypeID,
+		Sum:                sum,
+		SelectivePositions: selectivePositions,
+	})
+	return b
+}
+
+func (b *OrderBuilder) DiscountCard(track string) *OrderBuilder {
+	if b.order.DiscountsInfo == nil {
+		b.order.DiscountsInfo = &OrderDiscountsInfoRequestModel{}
+	}
+	b.order.DiscountsInfo.Card = &DiscountCardRequestModel{Track: track}
+	return b
+}
+
+func (b *OrderBuilder) LoyaltyCoupon(coupon string, appliedManualConditions ...string) *OrderBuilder {
+	b.order.LoyaltyInfo = &LoyaltyInfoModel{Coupon: &coupon, AppliedManualConditions: appliedManualConditions}
+	return b
+}
+
+// LoyaltyInfo задает данные лояльности, например из LoyaltyCalculateRequestModel.LoyaltyInfo
+func (b *OrderBuilder) LoyaltyInfo(info *LoyaltyInfoModel) *OrderBuilder {
+	b.order.LoyaltyInfo = info
+	return b
+}
+
+func (b *OrderBuilder) ExternalData(key, value string) *OrderBuilder {
+	b.order.ExternalData = append(b.order.ExternalData, ExternalDataModel{Key: key, Value: value})
+	return b
+}
+
+// Build проверяет заказ на очевидные ошибки и возвращает готовую модель
+func (b *OrderBuilder) Build() (*OrderRequestModel, error) {
+	if len(b.order.Items) == 0 && len(b.order.Combos) == 0 {
+		return nil, errors.New("заказ не содержит позиций")
+	}
+	for i, item := range b.order.Items {
+		if item.Type == OrderItemTypeCompound {
+			if item.PrimaryComponent == nil || item.PrimaryComponent.ProductID == "" {
+				return nil, fmt.Errorf("позиция %d: не указан основной компонент", i)
+			}
+			if item.SecondaryComponent != nil && item.SecondaryComponent.ProductID == "" {
+				return nil, fmt.Errorf("позиция %d: пустой productId второго компонента", i)
+			}
+		} else if item.ProductID == "" {
+			return nil, fmt.Errorf("позиция %d: пустой productId", i)
+		}
+		if item.Amount <= 0 {
+			return nil, fmt.Errorf("позиция %d: количество должно быть больше нуля", i)
+		}
+		if err := checkOrderModifiers(i, "модификатор", item.Modifiers); err != nil {
+			return nil, err
+		}
+		if err := checkOrderModifiers(i, "общий модификатор", item.CommonModifiers); err != nil {
+			return nil, err
+		}
+		for _, c := range []*OrderItemComponentRequestModel{item.PrimaryComponent, item.SecondaryComponent} {
+			if c == nil {
+				continue
+			}
+			if err := checkOrderModifiers(i, "модификатор компонента", c.Modifiers); err != nil {
+				return nil, err
+			}
+		}
+	}
+	for i, p := range b.order.Payments {
+		if p.PaymentTypeID == "" || p.PaymentTypeKind == "" {
+			return nil, fmt.Errorf("оплата %d: не указан тип оплаты", i)
+		}
+		if p.Sum < 0 {
+			return nil, fmt.Errorf("оплата %d: отрицательная сумма", i)
+		}
+	}
+	// копируем слайсы, чтобы последующие Add* не меняли уже собранный заказ
+	out := b.order
+	out.TableIDs = append([]string(nil), b.order.TableIDs...)
+	out.Items = make([]OrderItemRequestModel, len(b.order.Items))
+	for i, item := range b.order.Items {
+		out.Items[i] = copyOrderItem(item)
+	}
+	out.Combos = append([]OrderComboRequestModel(nil), b.order.Combos...)
+	out.Payments = append([]OrderPaymentRequestModel(nil), b.order.Payments...)
+	out.Tips = append([]OrderTipsRequestModel(nil), b.order.Tips...)
+	out.ExternalData = append([]ExternalDataModel(nil), b.order.ExternalData...)
+	if b.order.DiscountsInfo != nil {
+		discounts := *b.order.DiscountsInfo
+		discounts.Discounts = append([]OrderDiscountRequestModel(nil), discounts.Discounts...)
+		out.DiscountsInfo = &discounts
+	}
+	return &out, nil
+}
+
+func checkOrderModifiers(item int, kind string, modifiers []OrderItemModifierRequestModel) error {
+	for j, mod := range modifiers {
+		if mod.ProductID == "" {
+			return fmt.Errorf("позиция %d, %s %d: пустой productId", item, kind, j)
+		}
+	}
+	return nil
+}
+
+// copyOrderItem копирует позицию вместе с вложенными слайсами модификаторов
+func copyOrderItem(item OrderItemRequestModel) OrderItemRequestModel {
+	item.Modifiers = append([]OrderItemModifierRequestModel(nil), item.Modifiers...)
+	item.CommonModifiers = append([]OrderItemModifierRequestModel(nil), item.CommonModifiers...)
+	if item.PrimaryComponent != nil {
+		c := *item.PrimaryComponent
+		c.Modifiers = append([]OrderItemModifierRequestModel(nil), c.Modifiers...)
+		item.PrimaryComponent = &c
+	}
+	if item.SecondaryComponent != nil {
+		c := *item.SecondaryComponent
+		c.Modifiers = append([]OrderItemModifierRequestModel(nil), c.Modifiers...)
+		item.SecondaryComponent = &c
+	}
+	return item
+}
@@ -15,6 +15,15 @@ var _ IOrders = (*Orders)(nil)
 
 // OrderCreate реплицирует Orders.order_create
 func (o *Orders) OrderCreate(ctx context.Context, organizationID, terminalGroupID string, order map[string]any, createOrderSettings *int) (*BaseCreatedOrderInfoModel, *CustomErrorModel, error) {
+	return o.orderCreate(ctx, organizationID, terminalGroupID, order, createOrderSettings)
+}
+
+// OrderCreateTyped создает заказ из типизированной модели (см. NewOrderBuilder)
+func (o *Orders) OrderCreateTyped(ctx context.Context, organizationID, terminalGroupID string, order *OrderRequestModel, createOrderSettings *int) (*BaseCreatedOrderInfoModel, *CustomErrorModel, error) {
+	return o.orderCreate(ctx, organizationID, terminalGroupID, order, createOrderSettings)
+}
+
+func (o *Orders) orderCreate(ctx context.Context, organizationID, terminalGroupID string, order any, createOrderSettings *int) (*BaseCreatedOrderInfoModel, *CustomErrorModel, error) {
 	data := map[string]any{
 		"organizationId": organizationID,
 		"terminalGroupId": terminalGroupID,
@@ -25,11 +34,12 @@ func (o *Orders) OrderCreate(ctx context.Context, organizationID, terminalGroupI
 	}
 	body, status, err := o.client.post(ctx, "/api/1/order/create", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/order/create", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseCreatedOrderInfoModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	if cerr, err := o.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil { return &out, cerr, err }
 	return &out, nil, nil
 }
 
@@ -45,10 +55,100 @@ func (o *Orders) OrderByID(ctx context.Context, organizationIDs []string, orderI
 	
 	body, status, err := o.client.post(ctx, "/api/1/order/by_id", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/order/by_id", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out ByIdModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
 	return &out, nil, nil
 }
+
+// AddItems реплицирует Orders.add_items
+// Добавляет позиции и комбо в заказ на стол. Метод-команда: статус через Commands.Status
+func (o *Orders) AddItems(ctx context.Context, organizationID, orderID string, items []OrderItemRequestModel, combos []OrderComboRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"items": items,
+	}
+	if len(combos) > 0 { data["combos"] = combos }
+	return o.client.command(ctx, "/api/1/order/add_items", organizationID, data)
+}
+
+// AddPayments реплицирует Orders.add_payments
+// Добавляет оплаты и чаевые в заказ на стол. Метод-команда: статус через Commands.Status
+func (o *Orders) AddPayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"payments": payments,
+	}
+	if len(tips) > 0 { data["tips"] = tips }
+	return o.client.command(ctx, "/api/1/order/add_payments", organizationID, data)
+}
+
+// ChangePayments реплицирует Orders.change_payments
+// Заменяет оплаты заказа на стол. Метод-команда: статус через Commands.Status
+func (o *Orders) ChangePayments(ctx context.Context, organizationID, orderID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+		"payments": payments,
+	}
+	if len(tips) > 0 { data["tips"] = tips }
+	return o.client.command(ctx, "/api/1/order/change_payments", organizationID, data)
+}
+
+// Close реплицирует Orders.close
+// Закрывает заказ на стол. Метод-команда: статус через Commands.Status
+func (o *Orders) Close(ctx context.Context, organizationID, orderID string, chequeAdditionalInfo *ChequeAdditionalInfoModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"orderId": orderID,
+	}
+	if chequeAdditionalInfo != nil { data["chequeAdditionalInfo"] = chequeAdditionalInfo }
+	return o.client.command(ctx, "/api/1/order/close", organizationID, data)
+}
+
+// ByTable реплицирует Orders.by_table
+func (o *Orders) ByTable(ctx context.Context, organizationIDs, tableIDs, statuses []string, dateFrom, dateTo *string, sourceKeys []string) (*ByTableModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"tableIds": tableIDs,
+	}
+	if len(statuses) > 0 { data["statuses"] = statuses }
+	if dateFrom != nil { data["dateFrom"] = *dateFrom }
+	if dateTo != nil { data["dateTo"] = *dateTo }
+	if len(sourceKeys) > 0 { data["sourceKeys"] = sourceKeys }
+
+	body, status, err := o.client.post(ctx, "/api/1/order/by_table", data)
+	if err != nil { return nil, nil, err }
+	if cerr := detectCustomError("/api/1/order/by_table", status, body); cerr != nil {
+		return nil, cerr, nil
+	}
+	var out ByTableModel
+	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
+	return &out, nil, nil
+}
+
+// InitByTable реплицирует Orders.init_by_table
+// Загружает в iikoTransport заказы, открытые на терминале для столов. Метод-команда: статус через Commands.Status
+func (o *Orders) InitByTable(ctx context.Context, organizationID, terminalGroupID string, tableIDs []string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"terminalGroupId": terminalGroupID,
+		"tableIds": tableIDs,
+	}
+	return o.client.command(ctx, "/api/1/order/init_by_table", organizationID, data)
+}
+
+// InitByPosOrder реплицирует Orders.init_by_pos_order
+// Загружает в iikoTransport заказы по их id на терминале. Метод-команда: статус через Commands.Status
+func (o *Orders) InitByPosOrder(ctx context.Context, organizationID, terminalGroupID string, posOrderIDs []string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"terminalGroupId": terminalGroupID,
+		"posOrderIds": posOrderIDs,
+	}
+	return o.client.command(ctx, "/api/1/order/init_by_posOrder", organizationID, data)
+}
@@ -0,0 +1,157 @@
+package goiikoapi
+
+import (
+	"context"
+	"strings"
+	"sync"
+	"time"
+)
+
+// RateLimit параметры token bucket: Rate запросов в секунду и емкость Burst.
+// Rate <= 0 отключает ограничение.
+type RateLimit struct {
+	Rate  float64
+	Burst int
+}
+
+// RateLimiterConfig настройки RateLimiter
+type RateLimiterConfig struct {
+	// Default лимит для семейств, не указанных в Families
+	Default RateLimit
+	// Families лимиты по семействам методов. Семейство — первый сегмент пути после версии API:
+	// "/api/1/deliveries/create" -> "deliveries", "/api/1/loyalty/iiko/customer/info" -> "loyalty",
+	// "/api/2/menu/by_id" -> "menu"
+	Families map[string]RateLimit
+	// PerOrganization заводит отдельное ведро для каждой организации из тела запроса
+	PerOrganization bool
+}
+
+// RateLimiter клиентский ограничитель частоты запросов. Ведра ведутся отдельно для каждого
+// apiLogin, поэтому один RateLimiter можно передать в несколько Client с одним apiLogin
+// через WithRateLimiter, чтобы они делили квоту.
+type RateLimiter struct {
+	cfg     RateLimiterConfig
+	mu      sync.Mutex
+	buckets map[string]*tokenBucket
+}
+
+// NewRateLimiter создает ограничитель
+func NewRateLimiter(cfg RateLimiterConfig) *RateLimiter {
+	return &RateLimiter{cfg: cfg, buckets: map[string]*tokenBucket{}}
+}
+
+// WithRateLimiter подключает ограничитель частоты запросов к клиенту
+func WithRateLimiter(l *RateLimiter) Option {
+	return func(c *Client) { c.limiter = l }
+}
+
+// Wait блокирует до появления свободного токена в ведре или отмены ctx
+func (l *RateLimiter) Wait(ctx context.Context, apiLogin, endpoint, organizationID string) error {
+	if l == nil {
+		return nil
+	}
+	family := endpointFamily(endpoint)
+	limit, ok := l.cfg.Families[family]
+	if !ok {
+		limit = l.cfg.Default
+	}
+	if limit.Rate <= 0 {
+		return nil
+	}
+	key := apiLogin + "|" + family
+	if l.cfg.PerOrganization && organizationID != "" {
+		key += "|" + organizationID
+	}
+
+	l.mu.Lock()
+	b, ok := l.buckets[key]
+	if !ok {
+		b = newTokenBucket(limit)
+		l.buckets[key] = b
+	}
+	l.mu.Unlock()
+
+	d := b.reserve(time.Now())
+	if d <= 0 {
+		return nil
+	}
+	if err := sleepContext(ctx, d); err != nil {
+		b.cancel()
+		return err
+	}
+	return nil
+}
+
+type tokenBucket struct {
+	mu     sync.Mutex
+	rate   float64
+	burst  float64
+	tokens float64
+	last   time.Time
+}
+
+func newTokenBucket(limit RateLimit) *tokenBucket {
+	burst := float64(limit.Burst)
+	if burst < 1 {
+		burst = 1
+	}
+	return &tokenBucket{rate: limit.Rate, burst: burst, tokens: burst, last: time.Now()}
+}
+
+// reserve занимает токен и возвращает время ожидания до его появления
+func (b *tokenBucket) reserve(now time.Time) time.Duration {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.tokens += now.Sub(b.last).Seconds() * b.rate
+	if b.tokens > b.burst {
+		b.tokens = b.burst
+	}
+	b.last = now
+	b.tokens--
+	if b.tokens >= 0 {
+		return 0
+	}
+	return time.Duration(-b.tokens / b.rate * float64(time.Second))
+}
+
+// cancel возвращает токен, занятый отмененным ожиданием
+func (b *tokenBucket) cancel() {
+	b.mu.Lock()
+	b.tokens++
+	b.mu.Unlock()
+}
+
+// endpointFamily возвращает семейство метода по пути запроса
+func endpointFamily(endpoint string) string {
+	parts := strings.Split(strings.Trim(endpoint, "/"), "/")
+	// api/<version>/<family>/...
+	if len(parts) >= 3 && parts[0] == "api" {
+		return parts[2]
+	}
+	return endpoint
+}
+
+// payloadOrganizationID извлекает organizationId (или первый из organizationIds) из тела-map.
+// Для тел-структур организацию передает вызывающий метод (Client.queryFor, Client.command).
+func payloadOrganizationID(payload any) string {
+	data, ok := payload.(map[string]any)
+	if !ok {
+		return ""
+	}
+	if id, ok := data["organizationId"].(string); ok {
+		return id
+	}
+	switch ids := data["organizationIds"].(type) {
+	case []string:
+		if len(ids) > 0 {
+			return ids[0]
+		}
+	case []any:
+		// map из requestData: массивы после JSON приходят как []any
+		if len(ids) > 0 {
+			id, _ := ids[0].(string)
+			return id
+		}
+	}
+	return ""
+}
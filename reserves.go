@@ -0,0 +1,183 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+	"time"
+)
+
+// Reserves содержит методы для работы с резервами и банкетами
+type Reserves struct {
+	client *Client
+}
+
+// Проверяем, что Reserves реализует IReserves
+var _ IReserves = (*Reserves)(nil)
+
+// AvailableOrganizations реплицирует Reserves.available_organizations
+func (r *Reserves) AvailableOrganizations(ctx context.Context, organizationIDs []string, includeDisabled *bool) (*BaseReserveOrganizationsModel, *CustomErrorModel, error) {
+	data := map[string]any{}
+	if len(organizationIDs) > 0 {
+		data["organizationIds"] = organizationIDs
+	}
+	if includeDisabled != nil {
+		data["includeDisabled"] = *includeDisabled
+	}
+	var out BaseReserveOrganizationsModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/available_organizations", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// AvailableTerminalGroups реплицирует Reserves.available_terminal_groups
+func (r *Reserves) AvailableTerminalGroups(ctx context.Context, organizationIDs []string) (*BaseTerminalGroupsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+	}
+	var out BaseTerminalGroupsModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/available_terminal_groups", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// AvailableRestaurantSections реплицирует Reserves.available_restaurant_sections.
+// Возвращает залы со столами; returnSchema добавляет схему зала.
+func (r *Reserves) AvailableRestaurantSections(ctx context.Context, terminalGroupIDs []string, returnSchema bool, revision *int64) (*BaseRestaurantSectionsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"terminalGroupIds": terminalGroupIDs,
+		"returnSchema":     returnSchema,
+	}
+	if revision != nil {
+		data["revision"] = *revision
+	}
+	var out BaseRestaurantSectionsModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/available_restaurant_sections", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// RestaurantSectionsWorkload реплицирует Reserves.restaurant_sections_workload
+func (r *Reserves) RestaurantSectionsWorkload(ctx context.Context, restaurantSectionIDs []string, dateFrom time.Time, dateTo *time.Time) (*BaseSectionsWorkloadModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"restaurantSectionIds": restaurantSectionIDs,
+		"dateFrom":             dateFrom.Format(TimeLayout),
+	}
+	if dateTo != nil {
+		data["dateTo"] = dateTo.Format(TimeLayout)
+	}
+	var out BaseSectionsWorkloadModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/restaurant_sections_workload", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Create реплицирует Reserves.create. Метод-команда: статус через Commands.Status
+func (r *Reserves) Create(ctx context.Context, organizationID, terminalGroupID string, reserve *ReserveRequestModel, createReserveSettings *int) (*BaseCreatedReserveInfoModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":  organizationID,
+		"terminalGroupId": terminalGroupID,
+	}
+	// поля резерва передаются на верхнем уровне запроса
+	if reserve != nil {
+		b, err := json.Marshal(reserve)
+		if err != nil {
+			return nil, nil, err
+		}
+		if err := json.Unmarshal(b, &data); err != nil {
+			return nil, nil, err
+		}
+	}
+	if createReserveSettings != nil {
+		data["createReserveSettings"] = map[string]int{"transportToFrontTimeout": *createReserveSettings}
+	}
+	var out BaseCreatedReserveInfoModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/create", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	if cerr, err := r.client.awaitCommand(ctx, organizationID, out.CorrelationID); cerr != nil || err != nil {
+		return &out, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// StatusByID реплицирует Reserves.status_by_id
+func (r *Reserves) StatusByID(ctx context.Context, organizationID string, reserveIDs, sourceKeys []string) (*BaseReservesModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"reserveIds":     reserveIDs,
+	}
+	if len(sourceKeys) > 0 {
+		data["sourceKeys"] = sourceKeys
+	}
+	var out BaseReservesModel
+	if cerr, err := r.client.query(ctx, "/api/1/reserve/status_by_id", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Cancel реплицирует Reserves.cancel; cancelReason — одна из констант ReserveCancelReason*
+func (r *Reserves) Cancel(ctx context.Context, organizationID, reserveID, cancelReason string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"reserveId":      reserveID,
+		"cancelReason":   cancelReason,
+	}
+	return r.client.command(ctx, "/api/1/reserve/cancel", organizationID, data)
+}
+
+// AddItems реплицирует Reserves.add_items
+func (r *Reserves) AddItems(ctx context.Context, organizationID, reserveID string, items []OrderItemRequestModel, combos []OrderComboRequestModel, guests *OrderGuestsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"reserveId":      reserveID,
+		"items":          items,
+	}
+	if len(combos) > 0 {
+		data["combos"] = combos
+	}
+	if guests != nil {
+		data["guests"] = guests
+	}
+	return r.client.command(ctx, "/api/1/reserve/add_items", organizationID, data)
+}
+
+// AddPayments реплицирует Reserves.add_payments (предоплата банкета)
+func (r *Reserves) AddPayments(ctx context.Context, organizationID, reserveID string, payments []OrderPaymentRequestModel, tips []OrderTipsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"reserveId":      reserveID,
+		"payments":       payments,
+	}
+	if len(tips) > 0 {
+		data["tips"] = tips
+	}
+	return r.client.command(ctx, "/api/1/reserve/add_payments", organizationID, data)
+}
+
+// ChangeGuestsCount реплицирует Reserves.change_guests_count
+func (r *Reserves) ChangeGuestsCount(ctx context.Context, organizationID, reserveID string, guestsCount int) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId": organizationID,
+		"reserveId":      reserveID,
+		"guestsCount":    guestsCount,
+	}
+	return r.client.command(ctx, "/api/1/reserve/change_guests_count", organizationID, data)
+}
+
+// ChangeStartTime реплицирует Reserves.change_start_time; durationInMinutes меняет длительность, если задан
+func (r *Reserves) ChangeStartTime(ctx context.Context, organizationID, reserveID string, estimatedStartTime time.Time, durationInMinutes *int) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":     organizationID,
+		"reserveId":          reserveID,
+		"estimatedStartTime": estimatedStartTime.Format(TimeLayout),
+	}
+	if durationInMinutes != nil {
+		data["durationInMinutes"] = *durationInMinutes
+	}
+	return r.client.command(ctx, "/api/1/reserve/change_start_time", organizationID, data)
+}
@@ -0,0 +1,196 @@
+package goiikoapi
+
+import (
+	"context"
+	"errors"
+	"math/rand"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// RetryPolicy политика повторов запросов к iiko (см. WithRetryPolicy).
+// Нулевые значения полей заменяются значениями по умолчанию, кроме Jitter и RetryNonIdempotent.
+type RetryPolicy struct {
+	// MaxAttempts общее число попыток, включая первую (по умолчанию 3)
+	MaxAttempts int
+	// BaseDelay пауза перед первым повтором, далее удваивается (по умолчанию 200ms)
+	BaseDelay time.Duration
+	// MaxDelay верхняя граница паузы (по умолчанию 10s). Если Retry-After больше MaxDelay,
+	// повтор не выполняется и возвращается последний ответ
+	MaxDelay time.Duration
+	// Jitter доля случайного разброса паузы от 0 до 1
+	Jitter float64
+	// RetryableStatuses HTTP-статусы, при которых запрос повторяется (по умолчанию 429, 500, 502, 503, 504)
+	RetryableStatuses []int
+	// RetryNonIdempotent разрешает повтор неидемпотентных запросов (создание заказов, списания)
+	// при сетевых ошибках и 5xx. Ответ 429 повторяется всегда: iiko не принял такой запрос.
+	RetryNonIdempotent bool
+	// Endpoints переопределяет политику для конкретных путей, например "/api/1/order/create"
+	Endpoints map[string]*RetryPolicy
+}
+
+// DefaultRetryPolicy политика с 3 попытками, экспоненциальной паузой и 20% разбросом
+func DefaultRetryPolicy() *RetryPolicy {
+	return &RetryPolicy{
+		MaxAttempts: 3,
+		BaseDelay:   200 * time.Millisecond,
+		MaxDelay:    10 * time.Second,
+		Jitter:      0.2,
+	}
+}
+
+var defaultRetryableStatuses = []int{
+	http.StatusTooManyRequests,
+	http.StatusInternalServerError,
+	http.StatusBadGateway,
+	http.StatusServiceUnavailable,
+	http.StatusGatewayTimeout,
+}
+
+// nonIdempotentEndpoints методы, повтор которых может создать дубль операции
+var nonIdempotentEndpoints = map[string]bool{
+	"/api/1/order/create":                           true,
+	"/api/1/order/add_items":                        true,
+	"/api/1/order/add_payments":                     true,
+	"/api/1/deliveries/create":                      true,
+	"/api/1/deliveries/add_items":                   true,
+	"/api/1/reserve/create":                         true,
+	"/api/1/reserve/add_items":                      true,
+	"/api/1/reserve/add_payments":                   true,
+	"/api/1/notifications/send":                     true,
+	"/api/1/loyalty/iiko/customer/wallet/hold":      true,
+	"/api/1/loyalty/iiko/customer/wallet/topup":     true,
+	"/api/1/loyalty/iiko/customer/wallet/chargeoff": true,
+	"/api/1/loyalty/iiko/message/send_sms":          true,
+	"/api/1/loyalty/iiko/message/send_email":        true,
+	"/api/1/order/change_payments":                  true,
+	"/api/1/order/close":                            true,
+	"/api/1/deliveries/change_payments":             true,
+	"/api/1/deliveries/cancel":                      true,
+	"/api/1/deliveries/print_delivery_bill":         true,
+	"/api/1/loyalty/iiko/customer/program/add":      true,
+	"/api/1/loyalty/iiko/customer/card/add":         true,
+	"/api/1/stop_lists/add":                         true,
+}
+
+// forEndpoint возвращает политику для пути с учетом Endpoints. Для nil-политики повторов нет
+func (p *RetryPolicy) forEndpoint(endpoint string) *RetryPolicy {
+	if p == nil {
+		return nil
+	}
+	if override, ok := p.Endpoints[endpoint]; ok && override != nil {
+		return override
+	}
+	return p
+}
+
+func (p *RetryPolicy) shouldRetry(endpoint string, attempt, status int, err error) bool {
+	if p == nil {
+		return false
+	}
+	maxAttempts := p.MaxAttempts
+	if maxAttempts <= 0 {
+		maxAttempts = 3
+	}
+	if attempt >= maxAttempts {
+		return false
+	}
+	if err != nil {
+		if errors.Is(err, context.Canceled) || errors.Is(err, context.DeadlineExceeded) {
+			return false
+		}
+		return p.RetryNonIdempotent || !nonIdempotentEndpoints[endpoint]
+	}
+	if !p.retryableStatus(status) {
+		return false
+	}
+	if status == http.StatusTooManyRequests {
+		return true
+	}
+	return p.RetryNonIdempotent || !nonIdempotentEndpoints[endpoint]
+}
+
+func (p *RetryPolicy) retryableStatus(status int) bool {
+	statuses := p.RetryableStatuses
+	if len(statuses) == 0 {
+		statuses = defaultRetryableStatuses
+	}
+	for _, s := range statuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// delay пауза перед повтором attempt+1: Retry-After, если он больше экспоненциальной паузы.
+// ok == false, если сервер просит ждать дольше MaxDelay
+func (p *RetryPolicy) delay(attempt int, header http.Header) (d time.Duration, ok bool) {
+	base, maxDelay := p.BaseDelay, p.MaxDelay
+	if base <= 0 {
+		base = 200 * time.Millisecond
+	}
+	if maxDelay <= 0 {
+		maxDelay = 10 * time.Second
+	}
+	d = base << uint(attempt-1)
+	if d <= 0 || d > maxDelay {
+		d = maxDelay
+	}
+	if p.Jitter > 0 {
+		d += time.Duration((retryJitter()*2 - 1) * p.Jitter * float64(d))
+	}
+	if d > maxDelay {
+		d = maxDelay
+	}
+	if ra := parseRetryAfter(header); ra > maxDelay {
+		return 0, false
+	} else if ra > d {
+		d = ra
+	}
+	return d, true
+}
+
+// retryRand источник разброса пауз; в go1.19 глобальный math/rand не инициализирован случайно,
+// и без своего источника все процессы делали бы одинаковые паузы
+var (
+	retryRandMu sync.Mutex
+	retryRand   = rand.New(rand.NewSource(time.Now().UnixNano()))
+)
+
+func retryJitter() float64 {
+	retryRandMu.Lock()
+	defer retryRandMu.Unlock()
+	return retryRand.Float64()
+}
+
+// parseRetryAfter разбирает заголовок Retry-After в секундах или в формате HTTP-date
+func parseRetryAfter(header http.Header) time.Duration {
+	v := header.Get("Retry-After")
+	if v == "" {
+		return 0
+	}
+	if secs, err := strconv.Atoi(v); err == nil && secs > 0 {
+		return time.Duration(secs) * time.Second
+	}
+	if t, err := http.ParseTime(v); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func sleepContext(ctx context.Context, d time.Duration) error {
+	if d <= 0 {
+		return ctx.Err()
+	}
+	t := time.NewTimer(d)
+	defer t.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-t.C:
+		return nil
+	}
+}
@@ -0,0 +1,50 @@
+package goiikoapi
+
+import (
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRetryPolicyDelay(t *testing.T) {
+	retryAfter := func(v string) http.Header {
+		h := http.Header{}
+		h.Set("Retry-After", v)
+		return h
+	}
+	tests := []struct {
+		name    string
+		policy  RetryPolicy
+		attempt int
+		header  http.Header
+		want    time.Duration
+		wantOK  bool
+	}{
+		{name: "defaults", attempt: 1, want: 200 * time.Millisecond, wantOK: true},
+		{name: "exponential", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 3, want: 4 * time.Second, wantOK: true},
+		{name: "capped by max delay", policy: RetryPolicy{BaseDelay: time.Second, MaxDelay: 5 * time.Second}, attempt: 4, want: 5 * time.Second, wantOK: true},
+		{name: "shift overflow", policy: RetryPolicy{BaseDelay: time.Second}, attempt: 80, want: 10 * time.Second, wantOK: true},
+		{name: "retry-after above backoff", attempt: 1, header: retryAfter("3"), want: 3 * time.Second, wantOK: true},
+		{name: "retry-after below backoff", policy: RetryPolicy{BaseDelay: 5 * time.Second}, attempt: 1, header: retryAfter("1"), want: 5 * time.Second, wantOK: true},
+		{name: "retry-after above max delay", attempt: 1, header: retryAfter("30"), wantOK: false},
+		{name: "invalid retry-after", attempt: 1, header: retryAfter("soon"), want: 200 * time.Millisecond, wantOK: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, ok := tt.policy.delay(tt.attempt, tt.header)
+			if got != tt.want || ok != tt.wantOK {
+				t.Errorf("delay() = %v, %v, want %v, %v", got, ok, tt.want, tt.wantOK)
+			}
+		})
+	}
+}
+
+func TestRetryPolicyDelayJitter(t *testing.T) {
+	p := RetryPolicy{BaseDelay: time.Second, Jitter: 0.2}
+	for i := 0; i < 100; i++ {
+		d, ok := p.delay(1, nil)
+		if !ok || d < 800*time.Millisecond || d > 1200*time.Millisecond {
+			t.Fatalf("delay() = %v, %v, want 800ms..1200ms", d, ok)
+		}
+	}
+}
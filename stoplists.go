@@ -0,0 +1,193 @@
+package goiikoapi
+
+import "context"
+
+// StopLists содержит методы для работы со стоп-листами
+type StopLists struct {
+	client *Client
+}
+
+// Проверяем, что StopLists реализует IStopLists
+var _ IStopLists = (*StopLists)(nil)
+
+// StopLists реплицирует StopLists.stop_lists; terminalGroupIDs ограничивает список групп
+func (s *StopLists) StopLists(ctx context.Context, organizationIDs, terminalGroupIDs []string, returnSize bool) (*BaseStopListsModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationIds": organizationIDs,
+		"returnSize":      returnSize,
+	}
+	if len(terminalGroupIDs) > 0 {
+		data["terminalGroupsIds"] = terminalGroupIDs
+	}
+	var out BaseStopListsModel
+	if cerr, err := s.client.query(ctx, "/api/1/stop_lists", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Check реплицирует StopLists.check: возвращает позиции заказа, попавшие в стоп-лист
+func (s *StopLists) Check(ctx context.Context, organizationID, terminalGroupID string, items []OrderItemRequestModel) (*BaseStopListCheckModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":  organizationID,
+		"terminalGroupId": terminalGroupID,
+		"items":           items,
+	}
+	var out BaseStopListCheckModel
+	if cerr, err := s.client.query(ctx, "/api/1/stop_lists/check", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// Add реплицирует StopLists.add
+func (s *StopLists) Add(ctx context.Context, organizationID, terminalGroupID string, items []StopListAddItemModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":  organizationID,
+		"terminalGroupId": terminalGroupID,
+		"items":           items,
+	}
+	return s.client.command(ctx, "/api/1/stop_lists/add", organizationID, data)
+}
+
+// Remove реплицирует StopLists.remove
+func (s *StopLists) Remove(ctx context.Context, organizationID, terminalGroupID string, items []StopListRemoveItemModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":  organizationID,
+		"terminalGroupId": terminalGroupID,
+		"items":           items,
+	}
+	return s.client.command(ctx, "/api/1/stop_lists/remove", organizationID, data)
+}
+
+// Clear реплицирует StopLists.clear
+func (s *StopLists) Clear(ctx context.Context, organizationID, terminalGroupID string) (*BaseResponseModel, *CustomErrorModel, error) {
+	data := map[string]any{
+		"organizationId":  organizationID,
+		"terminalGroupId": terminalGroupID,
+	}
+	return s.client.command(ctx, "/api/1/stop_lists/clear", organizationID, data)
+}
+
+// StopListKey ключ остатка: продукт и размер (пустой SizeID — продукт без размера или все размеры)
+type StopListKey struct {
+	ProductID string
+	SizeID    string
+}
+
+// StopListBalances остатки стоп-листа одной терминальной группы
+type StopListBalances map[StopListKey]float64
+
+// Balance возвращает остаток продукта с размером; ok == false, если продукта нет в стоп-листе.
+// Если для размера записи нет, используется запись продукта без размера.
+func (b StopListBalances) Balance(productID, sizeID string) (balance float64, ok bool) {
+	if balance, ok = b[StopListKey{ProductID: productID, SizeID: sizeID}]; ok || sizeID == "" {
+		return balance, ok
+	}
+	balance, ok = b[StopListKey{ProductID: productID}]
+	return balance, ok
+}
+
+// Available сообщает, что продукт можно продать: его нет в стоп-листе или остаток больше нуля
+func (b StopListBalances) Available(productID, sizeID string) bool {
+	balance, ok := b.Balance(productID, sizeID)
+	return !ok || balance > 0
+}
+
+// Balances возвращает остатки стоп-листа терминальной группы
+func (m *BaseStopListsModel) Balances(organizationID, terminalGroupID string) StopListBalances {
+	out := StopListBalances{}
+	if m == nil {
+		return out
+	}
+	for _, org := range m.TerminalGroupStopLists {
+		if org.OrganizationID != organizationID {
+			continue
+		}
+		for _, tg := range org.Items {
+			if tg.TerminalGroupID != terminalGroupID {
+				continue
+			}
+			for _, it := range tg.Items {
+				key := StopListKey{ProductID: it.ProductID}
+				if it.SizeID != nil {
+					key.SizeID = *it.SizeID
+				}
+				out[key] = it.Balance
+			}
+		}
+	}
+	return out
+}
+
+// MarkNomenclature отмечает продукты номенклатуры, недоступные в терминальной группе tg:
+// Unavailable — продукт не продается ни в одном размере, UnavailableSizeIDs — закончившиеся размеры.
+// Возвращает число недоступных продуктов.
+func (m *BaseStopListsModel) MarkNomenclature(nom *BaseNomenclatureModel, tg TerminalGroupItemModel) int {
+	if nom == nil {
+		return 0
+	}
+	balances := m.Balances(tg.OrganizationID, tg.ID)
+	count := 0
+	for i := range nom.Products {
+		p := &nom.Products[i]
+		p.Unavailable, p.UnavailableSizeIDs = false, nil
+		sized := 0
+		for _, sp := range p.SizePrices {
+			if sp.SizeID == nil {
+				continue
+			}
+			sized++
+			if !balances.Available(p.ID, *sp.SizeID) {
+				p.UnavailableSizeIDs = append(p.UnavailableSizeIDs, *sp.SizeID)
+			}
+		}
+		if sized > 0 {
+			p.Unavailable = len(p.UnavailableSizeIDs) == sized
+		} else {
+			p.Unavailable = !balances.Available(p.ID, "")
+		}
+		if p.Unavailable {
+			count++
+		}
+	}
+	return count
+}
+
+// MarkMenu отмечает позиции, размеры и модификаторы внешнего меню, недоступные в терминальной группе tg.
+// Позиция недоступна, если недоступны все ее размеры. Возвращает число недоступных позиций.
+func (m *BaseStopListsModel) MarkMenu(menu *BaseMenuByIdModel, tg TerminalGroupItemModel) int {
+	if menu == nil {
+		return 0
+	}
+	balances := m.Balances(tg.OrganizationID, tg.ID)
+	count := 0
+	for c := range menu.ItemCategories {
+		items := menu.ItemCategories[c].Items
+		for i := range items {
+			item := &items[i]
+			item.Unavailable = !balances.Available(item.ItemID, "")
+			available := 0
+			for s := range item.ItemSizes {
+				size := &item.ItemSizes[s]
+				size.Unavailable = item.Unavailable || !balances.Available(item.ItemID, size.SizeID)
+				if !size.Unavailable {
+					available++
+				}
+				for g := range size.ItemModifierGroups {
+					mods := size.ItemModifierGroups[g].Items
+					for k := range mods {
+						mods[k].Unavailable = !balances.Available(mods[k].ItemID, "")
+					}
+				}
+			}
+			if len(item.ItemSizes) > 0 && available == 0 {
+				item.Unavailable = true
+			}
+			if item.Unavailable {
+				count++
+			}
+		}
+	}
+	return count
+}
@@ -24,8 +24,8 @@ func (tg *TerminalGroup) TerminalGroups(ctx context.Context, organizationIDs []s
 	}
 	body, status, err := tg.client.post(ctx, "/api/1/terminal_groups", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/terminal_groups", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseTerminalGroupsModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
@@ -43,8 +43,8 @@ func (tg *TerminalGroup) IsAlive(ctx context.Context, organizationIDs, terminalG
 	}
 	body, status, err := tg.client.post(ctx, "/api/1/terminal_groups/is_alive", data)
 	if err != nil { return nil, nil, err }
-	if msg, ok := DetectAPIError(body); ok {
-		return nil, &CustomErrorModel{ErrorModel: ErrorModel{ErrorDescription: msg}, StatusCode: status}, nil
+	if cerr := detectCustomError("/api/1/terminal_groups/is_alive", status, body); cerr != nil {
+		return nil, cerr, nil
 	}
 	var out BaseTGIsAliveModel
 	if err := json.Unmarshal(body, &out); err != nil { return nil, nil, err }
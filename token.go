@@ -0,0 +1,96 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"strings"
+	"time"
+)
+
+// tokenExpiryMargin запас до истечения токена, определенного по claim exp
+const tokenExpiryMargin = time.Minute
+
+// WithLazyToken откладывает получение токена до первого запроса (с контекстом вызывающего).
+// NewClient в этом режиме не обращается к сети.
+func WithLazyToken() Option {
+	return func(c *Client) { c.lazyToken = true }
+}
+
+// WithTokenTTL задает время жизни токена. Без опции срок берется из claim exp токена,
+// а если его нет — 15 минут.
+func WithTokenTTL(d time.Duration) Option {
+	return func(c *Client) { c.tokenTTL = d }
+}
+
+// WithTokenRefreshHook вызывает fn при каждой смене токена клиента
+func WithTokenRefreshHook(fn func(token string, expiresAt time.Time)) Option {
+	return func(c *Client) { c.onRefresh = fn }
+}
+
+// Token возвращает текущий токен доступа (пустая строка, если токен еще не получен)
+func (c *Client) Token() string {
+	return c.currentToken()
+}
+
+// TokenExpiresAt возвращает момент истечения текущего токена
+func (c *Client) TokenExpiresAt() time.Time {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.tokenExp
+}
+
+// RefreshToken получает новый токен. Если в TokenStore уже лежит более свежий токен,
+// полученный другим клиентом, используется он.
+func (c *Client) RefreshToken(ctx context.Context) error {
+	return c.refreshToken(ctx)
+}
+
+// InvalidateToken сбрасывает текущий токен в клиенте и в TokenStore;
+// следующий запрос получит новый токен
+func (c *Client) InvalidateToken() {
+	token := c.currentToken()
+	c.mu.Lock()
+	c.token = ""
+	c.tokenExp = time.Time{}
+	c.headers.Del("Authorization")
+	c.mu.Unlock()
+	if c.tokenStore == nil || token == "" {
+		return
+	}
+	st, ok, err := c.tokenStore.Get(context.Background(), c.tokenStoreKey())
+	if err == nil && ok && st.Token == token {
+		_ = c.tokenStore.Set(context.Background(), c.tokenStoreKey(), StoredToken{})
+	}
+}
+
+// tokenExpiry вычисляет срок действия токена: WithTokenTTL, claim exp или defaultTokenTTL
+func (c *Client) tokenExpiry(token string) time.Time {
+	now := time.Now()
+	if c.tokenTTL > 0 {
+		return now.Add(c.tokenTTL)
+	}
+	if exp, ok := jwtExpiry(token); ok && exp.After(now.Add(tokenExpiryMargin)) {
+		return exp.Add(-tokenExpiryMargin)
+	}
+	return now.Add(defaultTokenTTL)
+}
+
+// jwtExpiry извлекает claim exp из JWT без проверки подписи
+func jwtExpiry(token string) (time.Time, bool) {
+	parts := strings.Split(token, ".")
+	if len(parts) != 3 {
+		return time.Time{}, false
+	}
+	payload, err := base64.RawURLEncoding.DecodeString(parts[1])
+	if err != nil {
+		return time.Time{}, false
+	}
+	var claims struct {
+		Exp int64 `json:"exp"`
+	}
+	if err := json.Unmarshal(payload, &claims); err != nil || claims.Exp == 0 {
+		return time.Time{}, false
+	}
+	return time.Unix(claims.Exp, 0), true
+}
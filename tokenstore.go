@@ -0,0 +1,231 @@
+package goiikoapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+)
+
+// StoredToken токен доступа с моментом истечения
+type StoredToken struct {
+	Token     string    `json:"token"`
+	ExpiresAt time.Time `json:"expiresAt"`
+}
+
+// Valid сообщает, что токен задан и еще не истек на момент now
+func (t StoredToken) Valid(now time.Time) bool {
+	return t.Token != "" && now.Before(t.ExpiresAt)
+}
+
+// TokenStore хранилище токенов, общее для нескольких клиентов или процессов (см. WithTokenStore).
+// Ключ строится из apiLogin и appId, сами учетные данные в хранилище не попадают.
+type TokenStore interface {
+	// Get возвращает сохраненный токен; ok == false, если токена нет
+	Get(ctx context.Context, key string) (token StoredToken, ok bool, err error)
+	// Set сохраняет токен; пустой Token удаляет запись
+	Set(ctx context.Context, key string, token StoredToken) error
+}
+
+// WithTokenStore подключает хранилище токенов к клиенту
+func WithTokenStore(s TokenStore) Option {
+	return func(c *Client) { c.tokenStore = s }
+}
+
+// MemoryTokenStore хранит токены в памяти процесса; удобен для нескольких Client в одном сервисе
+type MemoryTokenStore struct {
+	mu     sync.RWMutex
+	tokens map[string]StoredToken
+}
+
+// NewMemoryTokenStore создает хранилище в памяти
+func NewMemoryTokenStore() *MemoryTokenStore {
+	return &MemoryTokenStore{tokens: map[string]StoredToken{}}
+}
+
+func (s *MemoryTokenStore) Get(_ context.Context, key string) (StoredToken, bool, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.tokens[key]
+	return t, ok, nil
+}
+
+func (s *MemoryTokenStore) Set(_ context.Context, key string, token StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if token.Token == "" {
+		delete(s.tokens, key)
+		return nil
+	}
+	s.tokens[key] = token
+	return nil
+}
+
+// FileTokenStore хранит токены в JSON-файле. Запись атомарна (временный файл + rename),
+// поэтому файл можно разделять между процессами на одной машине или общем томе.
+type FileTokenStore struct {
+	path string
+	mu   sync.Mutex
+}
+
+// NewFileTokenStore создает файловое хранилище; файл создается при первой записи
+func NewFileTokenStore(path string) *FileTokenStore {
+	return &FileTokenStore{path: path}
+}
+
+func (s *FileTokenStore) Get(_ context.Context, key string) (StoredToken, bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return StoredToken{}, false, err
+	}
+	t, ok := tokens[key]
+	return t, ok, nil
+}
+
+func (s *FileTokenStore) Set(_ context.Context, key string, token StoredToken) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	tokens, err := s.read()
+	if err != nil {
+		return err
+	}
+	if token.Token == "" {
+		delete(tokens, key)
+	} else {
+		tokens[key] = token
+	}
+	b, err := json.Marshal(tokens)
+	if err != nil {
+		return err
+	}
+	tmp, err := os.CreateTemp(filepath.Dir(s.path), filepath.Base(s.path)+".*.tmp")
+	if err != nil {
+		return err
+	}
+	if _, err := tmp.Write(b); err != nil {
+		tmp.Close()
+		os.Remove(tmp.Name())
+		return err
+	}
+	if err := tmp.Close(); err != nil {
+		os.Remove(tmp.Name())
+		return err
+	}
+	return os.Rename(tmp.Name(), s.path)
+}
+
+func (s *FileTokenStore) read() (map[string]StoredToken, error) {
+	tokens := map[string]StoredToken{}
+	b, err := os.ReadFile(s.path)
+	if errors.Is(err, os.ErrNotExist) {
+		return tokens, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(b) == 0 {
+		return tokens, nil
+	}
+	if err := json.Unmarshal(b, &tokens); err != nil {
+		return nil, err
+	}
+	return tokens, nil
+}
+
+// tokenStoreKey ключ токена в TokenStore: хеш apiLogin и appId
+func (c *Client) tokenStoreKey() string {
+	sum := sha256.Sum256([]byte(c.apiLogin + "|" + c.appId))
+	return hex.EncodeToString(sum[:16])
+}
+
+// refreshFlight общий результат одновременных обновлений токена
+type refreshFlight struct {
+	done chan struct{}
+	err  error
+}
+
+// refreshToken обновляет токен; параллельные вызовы ждут один запрос к /access_token
+func (c *Client) refreshToken(ctx context.Context) error {
+	c.refreshMu.Lock()
+	if f := c.refreshing; f != nil {
+		c.refreshMu.Unlock()
+		select {
+		case <-f.done:
+			return f.err
+		case <-ctx.Done():
+			return ctx.Err()
+		}
+	}
+	f := &refreshFlight{done: make(chan struct{})}
+	c.refreshing = f
+	c.refreshMu.Unlock()
+
+	// запрос не привязан к ctx вызвавшего: его отмена не должна ломать обновление для остальных
+	timeout := c.defaultTO
+	if timeout <= 0 {
+		timeout = defaultTimeout
+	}
+	go func() {
+		fetchCtx, cancel := context.WithTimeout(detachedContext{ctx}, timeout)
+		defer cancel()
+		f.err = c.loadOrFetchToken(fetchCtx)
+		c.refreshMu.Lock()
+		c.refreshing = nil
+		c.refreshMu.Unlock()
+		close(f.done)
+	}()
+	select {
+	case <-f.done:
+		return f.err
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// detachedContext сохраняет значения родительского контекста, но не его отмену и дедлайн
+// (аналог context.WithoutCancel из Go 1.21)
+type detachedContext struct {
+	parent context.Context
+}
+
+func (detachedContext) Deadline() (time.Time, bool) { return time.Time{}, false }
+func (detachedContext) Done() <-chan struct{}       { return nil }
+func (detachedContext) Err() error                  { return nil }
+func (d detachedContext) Value(key any) any         { return d.parent.Value(key) }
+
+// loadOrFetchToken берет свежий токен из TokenStore (если его обновил другой клиент) или запрашивает новый
+func (c *Client) loadOrFetchToken(ctx context.Context) error {
+	if c.tokenStore != nil {
+		st, ok, err := c.tokenStore.Get(ctx, c.tokenStoreKey())
+		if err == nil && ok && st.Valid(time.Now()) && st.Token != c.currentToken() {
+			c.setTokenLocked(st.Token, st.ExpiresAt)
+			if c.onRefresh != nil {
+				c.onRefresh(st.Token, st.ExpiresAt)
+			}
+			return nil
+		}
+	}
+	token, err := c.fetchToken(ctx)
+	if err != nil {
+		return err
+	}
+	expiresAt := c.tokenExpiry(token)
+	c.setTokenLocked(token, expiresAt)
+	if c.tokenStore != nil {
+		// ошибка записи не мешает работе клиента: токен уже получен, но о ней сообщаем
+		if err := c.tokenStore.Set(ctx, c.tokenStoreKey(), StoredToken{Token: token, ExpiresAt: expiresAt}); err != nil && c.logger != nil {
+			c.logger.Error("token store set failed", "error", err)
+		}
+	}
+	if c.onRefresh != nil {
+		c.onRefresh(token, expiresAt)
+	}
+	return nil
+}
@@ -0,0 +1,119 @@
+package goiikoapi
+
+import "context"
+
+// defaultTransactionsPageSize размер страницы транзакций по умолчанию
+const defaultTransactionsPageSize = 100
+
+// TransactionIterator постранично обходит транзакции кошельков гостя:
+// начисления, списания и холды (CustomerWalletHold, CustomerWalletTopup, CustomerWalletChargeoff).
+//
+//	it := goiikoapi.NewTransactionsByDateIterator(cli.Customers, "customerId", "orgId", from, to, 0)
+//	for it.Next(ctx) {
+//		for _, t := range it.Page() { ... }
+//	}
+//	if err := it.Err(); err != nil { ... }
+type TransactionIterator struct {
+	fetch    func(ctx context.Context) (*CustomerTransactionsModel, *CustomErrorModel, error)
+	pageSize int
+	page     []CustomerTransactionModel
+	revision int64
+	err      error
+	done     bool
+}
+
+// NewTransactionsByDateIterator обходит транзакции гостя за период dateFrom..dateTo (формат TimeLayout).
+// pageSize <= 0 — 100 транзакций на страницу.
+func NewTransactionsByDateIterator(customers ICustomers, customerID, organizationID, dateFrom, dateTo string, pageSize int) *TransactionIterator {
+	if pageSize <= 0 {
+		pageSize = defaultTransactionsPageSize
+	}
+	it := &TransactionIterator{pageSize: pageSize}
+	pageNumber := 0
+	it.fetch = func(ctx context.Context) (*CustomerTransactionsModel, *CustomErrorModel, error) {
+		out, cerr, err := customers.CustomerTransactionsByDate(ctx, customerID, organizationID, dateFrom, dateTo, pageNumber, pageSize)
+		if cerr == nil && err == nil {
+			pageNumber++
+		}
+		return out, cerr, err
+	}
+	return it
+}
+
+// NewTransactionsByRevisionIterator обходит транзакции гостя, созданные после revision.
+// После обхода Revision() можно сохранить и продолжить с нее позже.
+func NewTransactionsByRevisionIterator(customers ICustomers, customerID, organizationID string, revision int64, pageSize int) *TransactionIterator {
+	if pageSize <= 0 {
+		pageSize = defaultTransactionsPageSize
+	}
+	it := &TransactionIterator{pageSize: pageSize, revision: revision}
+	it.fetch = func(ctx context.Context) (*CustomerTransactionsModel, *CustomErrorModel, error) {
+		out, cerr, err := customers.CustomerTransactionsByRevision(ctx, customerID, organizationID, it.revision, pageSize)
+		if cerr != nil || err != nil {
+			return out, cerr, err
+		}
+		if out.LastRevision <= it.revision {
+			// ревизия не сдвинулась — новых транзакций нет
+			it.done = true
+			return out, nil, nil
+		}
+		it.revision = out.LastRevision
+		return out, nil, nil
+	}
+	return it
+}
+
+// Next загружает следующую страницу; false — страниц больше нет или произошла ошибка (см. Err)
+func (it *TransactionIterator) Next(ctx context.Context) bool {
+	if it.done || it.err != nil {
+		return false
+	}
+	out, cerr, err := it.fetch(ctx)
+	if err == nil {
+		err = cerr.Err()
+	}
+	if err != nil {
+		it.err, it.page = err, nil
+		return false
+	}
+	it.page = out.Transactions
+	if len(it.page) < it.pageSize {
+		it.done = true
+	}
+	return len(it.page) > 0
+}
+
+// Page транзакции текущей страницы
+func (it *TransactionIterator) Page() []CustomerTransactionModel {
+	return it.page
+}
+
+// Revision последняя полученная ревизия (для итератора по ревизии)
+func (it *TransactionIterator) Revision() int64 {
+	return it.revision
+}
+
+// Err первая ошибка обхода; ошибка iiko возвращается как *APIError
+func (it *TransactionIterator) Err() error {
+	return it.err
+}
+
+// FilterTransactions оставляет транзакции указанных типов, например для сверки
+// холдов, пополнений и списаний:
+//
+//	audit := goiikoapi.FilterTransactions(it.Page(),
+//		goiikoapi.CustomerTransactionTypeHoldMoney,
+//		goiikoapi.CustomerTransactionTypeRefillWallet,
+//		goiikoapi.CustomerTransactionTypePayFromWallet)
+func FilterTransactions(transactions []CustomerTransactionModel, types ...CustomerTransactionType) []CustomerTransactionModel {
+	var out []CustomerTransactionModel
+	for _, t := range transactions {
+		for _, typ := range types {
+			if t.Type == typ {
+				out = append(out, t)
+				break
+			}
+		}
+	}
+	return out
+}
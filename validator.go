@@ -0,0 +1,318 @@
+package goiikoapi
+
+import "fmt"
+
+// OrderViolationCode код нарушения, найденного OrderValidator
+type OrderViolationCode string
+
+const (
+	ViolationProductNotFound  OrderViolationCode = "ProductNotFound"
+	ViolationProductDeleted   OrderViolationCode = "ProductDeleted"
+	ViolationSizeRequired     OrderViolationCode = "SizeRequired"
+	ViolationSizeInvalid      OrderViolationCode = "SizeInvalid"
+	ViolationModifierNotFound OrderViolationCode = "ModifierNotFound"
+	ViolationModifierRequired OrderViolationCode = "ModifierRequired"
+	ViolationModifierAmount   OrderViolationCode = "ModifierAmount"
+	ViolationGroupRequired    OrderViolationCode = "GroupModifierRequired"
+	ViolationGroupAmount      OrderViolationCode = "GroupModifierAmount"
+)
+
+// OrderViolation нарушение правил номенклатуры в позиции заказа
+type OrderViolation struct {
+	ItemIndex  int                `json:"itemIndex"`
+	ProductID  string             `json:"productId"`
+	ModifierID string             `json:"modifierId,omitempty"`
+	GroupID    string             `json:"groupId,omitempty"`
+	Code       OrderViolationCode `json:"code"`
+	Message    string             `json:"message"`
+}
+
+// validatorLimit ограничение количества. max <= 0 означает отсутствие верхней границы
+type validatorLimit struct {
+	min      int
+	max      int
+	required bool
+}
+
+// check сравнивает дробное количество (например, 0.5 порции) с целочисленными границами
+func (l validatorLimit) check(amount float64) bool {
+	if amount < float64(l.min) {
+		return false
+	}
+	return l.max <= 0 || amount <= float64(l.max)
+}
+
+type validatorGroup struct {
+	limit       validatorLimit
+	childLimits bool
+	children    map[string]validatorLimit
+	childOrder  []string
+}
+
+type validatorScheme struct {
+	modifiers     map[string]validatorLimit
+	modifierOrder []string
+	groups        map[string]*validatorGroup
+	order         []string
+}
+
+type validatorProduct struct {
+	deleted     bool
+	sized       bool
+	defaultSize string
+	schemes     map[string]*validatorScheme
+}
+
+// OrderValidator проверяет черновик заказа по номенклатуре или внешнему меню
+// до вызова Orders.OrderCreate / Deliveries.DeliveryCreate
+type OrderValidator struct {
+	products map[string]*validatorProduct
+}
+
+// NewNomenclatureValidator строит валидатор по ответу Menu.Nomenclature
+func NewNomenclatureValidator(nom *BaseNomenclatureModel) *OrderValidator {
+	v := &OrderValidator{products: map[string]*validatorProduct{}}
+	if nom == nil {
+		return v
+	}
+	defaultSizes := map[string]bool{}
+	for _, s := range nom.Sizes {
+		if s.IsDefault != nil && *s.IsDefault {
+			defaultSizes[s.ID] = true
+		}
+	}
+	for _, p := range nom.Products {
+		scheme := &validatorScheme{modifiers: map[string]validatorLimit{}, groups: map[string]*validatorGroup{}}
+		for _, m := range p.Modifiers {
+			scheme.modifiers[m.ID] = validatorLimit{min: m.MinAmount, max: m.MaxAmount, required: m.Required != nil && *m.Required}
+			scheme.modifierOrder = append(scheme.modifierOrder, m.ID)
+		}
+		for _, g := range p.GroupModifiers {
+			if g == nil {
+				continue
+			}
+			group := &validatorGroup{
+				limit:       validatorLimit{min: g.MinAmount, max: g.MaxAmount, required: g.Required},
+				childLimits: g.ChildModifiersHaveMinMaxRestrictions != nil && *g.ChildModifiersHaveMinMaxRestrictions,
+				children:    map[string]validatorLimit{},
+			}
+			for _, c := range g.ChildModifiers {
+				group.children[c.ID] = validatorLimit{min: c.MinAmount, max: c.MaxAmount, required: c.Required != nil && *c.Required}
+				group.childOrder = append(group.childOrder, c.ID)
+			}
+			scheme.groups[g.ID] = group
+			scheme.order = append(scheme.order, g.ID)
+		}
+		product := &validatorProduct{
+			deleted: p.IsDeleted != nil && *p.IsDeleted,
+			schemes: map[string]*validatorScheme{},
+		}
+		for _, sp := range p.SizePrices {
+			if sp.SizeID == nil || *sp.SizeID == "" {
+				continue
+			}
+			product.sized = true
+			product.schemes[*sp.SizeID] = scheme
+			if defaultSizes[*sp.SizeID] {
+				product.defaultSize = *sp.SizeID
+			}
+		}
+		if !product.sized {
+			product.schemes[""] = scheme
+		}
+		v.products[p.ID] = product
+	}
+	return v
+}
+
+// NewMenuValidator строит валидатор по ответу Menu.MenuByID
+func NewMenuValidator(menu *BaseMenuByIdModel) *OrderValidator {
+	v := &OrderValidator{products: map[string]*validatorProduct{}}
+	if menu == nil {
+		return v
+	}
+	for _, category := range menu.ItemCategories {
+		for _, item := range category.Items {
+			product := &validatorProduct{schemes: map[string]*validatorScheme{}}
+			for _, size := range item.ItemSizes {
+				scheme := &validatorScheme{modifiers: map[string]validatorLimit{}, groups: map[string]*validatorGroup{}}
+				for _, g := range size.ItemModifierGroups {
+					group := &validatorGroup{
+						limit:       restrictionLimit(g.Restrictions),
+						childLimits: g.ChildModifiersHaveMinMaxRestrictions,
+						children:    map[string]validatorLimit{},
+					}
+					for _, c := range g.Items {
+						group.children[c.ItemID] = restrictionLimit(c.Restrictions)
+						group.childOrder = append(group.childOrder, c.ItemID)
+					}
+					scheme.groups[g.ItemGroupID] = group
+					scheme.order = append(scheme.order, g.ItemGroupID)
+				}
+				if size.SizeID != "" {
+					product.sized = true
+				}
+				if size.IsDefault != nil && *size.IsDefault {
+					product.defaultSize = size.SizeID
+				}
+				product.schemes[size.SizeID] = scheme
+			}
+			v.products[item.ItemID] = product
+		}
+	}
+	return v
+}
+
+func restrictionLimit(r RestrictionModel) validatorLimit {
+	return validatorLimit{min: r.MinQuantity, max: r.MaxQuantity, required: r.MinQuantity > 0}
+}
+
+// Validate проверяет все позиции заказа. Пустой результат означает отсутствие нарушений
+func (v *OrderValidator) Validate(order *OrderRequestModel) []OrderViolation {
+	if order == nil {
+		return nil
+	}
+	var out []OrderViolation
+	for i, item := range order.Items {
+		if item.Type != OrderItemTypeCompound {
+			out = append(out, v.ValidateItem(i, item)...)
+			continue
+		}
+		// половинки составной позиции проверяются как обычные продукты с размером позиции
+		for _, c := range []*OrderItemComponentRequestModel{item.PrimaryComponent, item.SecondaryComponent} {
+			if c == nil {
+				continue
+			}
+			out = append(out, v.ValidateItem(i, OrderItemRequestModel{
+				Type:          OrderItemTypeProduct,
+				ProductID:     c.ProductID,
+				ProductSizeID: item.ProductSizeID,
+				Modifiers:     c.Modifiers,
+			})...)
+		}
+	}
+	return out
+}
+
+// ValidateItem проверяет одну позицию заказа: наличие продукта, размер и модификаторы
+func (v *OrderValidator) ValidateItem(index int, item OrderItemRequestModel) []OrderViolation {
+	var out []OrderViolation
+	add := func(code OrderViolationCode, modifierID, groupID, msg string) {
+		out = append(out, OrderViolation{
+			ItemIndex:  index,
+			ProductID:  item.ProductID,
+			ModifierID: modifierID,
+			GroupID:    groupID,
+			Code:       code,
+			Message:    msg,
+		})
+	}
+
+	product, ok := v.products[item.ProductID]
+	if !ok {
+		add(ViolationProductNotFound, "", "", fmt.Sprintf("продукт %s не найден", item.ProductID))
+		return out
+	}
+	if product.deleted {
+		add(ViolationProductDeleted, "", "", fmt.Sprintf("продукт %s удален", item.ProductID))
+	}
+
+	sizeID := ""
+	if item.ProductSizeID != nil {
+		sizeID = *item.ProductSizeID
+	}
+	if sizeID == "" && product.sized {
+		switch {
+		case product.defaultSize != "":
+			sizeID = product.defaultSize
+		case len(product.schemes) == 1:
+			for id := range product.schemes {
+				sizeID = id
+			}
+		default:
+			add(ViolationSizeRequired, "", "", "не указан размер продукта")
+			return out
+		}
+	}
+	scheme, ok := product.schemes[sizeID]
+	if !ok {
+		add(ViolationSizeInvalid, "", "", fmt.Sprintf("размер %s недоступен для продукта", sizeID))
+		return out
+	}
+
+	single := map[string]float64{}
+	groups := map[string]map[string]float64{}
+	for _, m := range item.Modifiers {
+		amount := m.Amount
+		groupID := ""
+		if m.ProductGroupID != nil {
+			groupID = *m.ProductGroupID
+		}
+		if groupID == "" {
+			if _, ok := scheme.modifiers[m.ProductID]; ok {
+				single[m.ProductID] += amount
+				continue
+			}
+			for _, id := range scheme.order {
+				if _, ok := scheme.groups[id].children[m.ProductID]; ok {
+					groupID = id
+					break
+				}
+			}
+		}
+		group, ok := scheme.groups[groupID]
+		if !ok {
+			add(ViolationModifierNotFound, m.ProductID, groupID, fmt.Sprintf("модификатор %s не относится к продукту", m.ProductID))
+			continue
+		}
+		if _, ok := group.children[m.ProductID]; !ok {
+			add(ViolationModifierNotFound, m.ProductID, groupID, fmt.Sprintf("модификатор %s не входит в группу %s", m.ProductID, groupID))
+			continue
+		}
+		if groups[groupID] == nil {
+			groups[groupID] = map[string]float64{}
+		}
+		groups[groupID][m.ProductID] += amount
+	}
+
+	for _, id := range scheme.modifierOrder {
+		limit := scheme.modifiers[id]
+		amount := single[id]
+		if (limit.required || limit.min > 0) && amount == 0 {
+			add(ViolationModifierRequired, id, "", fmt.Sprintf("обязательный модификатор %s не выбран", id))
+			continue
+		}
+		if amount > 0 && !limit.check(amount) {
+			add(ViolationModifierAmount, id, "", fmt.Sprintf("количество модификатора %s должно быть от %d до %d", id, limit.min, limit.max))
+		}
+	}
+	for _, groupID := range scheme.order {
+		group := scheme.groups[groupID]
+		total := 0.0
+		for _, amount := range groups[groupID] {
+			total += amount
+		}
+		if (group.limit.required || group.limit.min > 0) && total == 0 {
+			add(ViolationGroupRequired, "", groupID, fmt.Sprintf("не выбраны модификаторы обязательной группы %s", groupID))
+			continue
+		}
+		if total > 0 && !group.limit.check(total) {
+			add(ViolationGroupAmount, "", groupID, fmt.Sprintf("количество модификаторов группы %s должно быть от %d до %d", groupID, group.limit.min, group.limit.max))
+		}
+		if !group.childLimits {
+			continue
+		}
+		for _, id := range group.childOrder {
+			limit := group.children[id]
+			amount := groups[groupID][id]
+			if (limit.required || limit.min > 0) && amount == 0 {
+				add(ViolationModifierRequired, id, groupID, fmt.Sprintf("обязательный модификатор %s не выбран", id))
+				continue
+			}
+			if amount > 0 && !limit.check(amount) {
+				add(ViolationModifierAmount, id, groupID, fmt.Sprintf("количество модификатора %s должно быть от %d до %d", id, limit.min, limit.max))
+			}
+		}
+	}
+	return out
+}
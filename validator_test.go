@@ -0,0 +1,120 @@
+package goiikoapi
+
+import (
+	"reflect"
+	"testing"
+)
+
+func testNomenclature() *BaseNomenclatureModel {
+	yes := true
+	small, large := "small", "large"
+	return &BaseNomenclatureModel{
+		Sizes: []SizeModel{{ID: small, IsDefault: &yes}, {ID: large}},
+		Products: []ProductModel{
+			{
+				ID:        "soup",
+				Modifiers: []ModifierModel{{ID: "bread", MinAmount: 1, MaxAmount: 2}},
+			},
+			{
+				ID:         "pizza",
+				SizePrices: []SizePriceItemModel{{SizeID: &small}, {SizeID: &large}},
+				GroupModifiers: []*GroupModifierModel{{
+					ID:        "sauce",
+					MinAmount: 1,
+					MaxAmount: 2,
+					ChildModifiers: []ModifierModel{
+						{ID: "ketchup", MaxAmount: 1},
+						{ID: "mayo", MaxAmount: 1},
+					},
+				}},
+			},
+			{ID: "old", IsDeleted: &yes},
+		},
+	}
+}
+
+func TestNomenclatureValidatorValidate(t *testing.T) {
+	large, xl := "large", "xl"
+	sauce := func(id string, amount float64) OrderItemModifierRequestModel {
+		return OrderItemModifierRequestModel{ProductID: id, Amount: amount}
+	}
+	tests := []struct {
+		name  string
+		items []OrderItemRequestModel
+		want  []OrderViolationCode
+	}{
+		{
+			name:  "valid product with modifier",
+			items: []OrderItemRequestModel{{ProductID: "soup", Amount: 1, Modifiers: []OrderItemModifierRequestModel{sauce("bread", 1)}}},
+		},
+		{
+			name:  "unknown product",
+			items: []OrderItemRequestModel{{ProductID: "tea", Amount: 1}},
+			want:  []OrderViolationCode{ViolationProductNotFound},
+		},
+		{
+			name:  "deleted product",
+			items: []OrderItemRequestModel{{ProductID: "old", Amount: 1}},
+			want:  []OrderViolationCode{ViolationProductDeleted},
+		},
+		{
+			name:  "modifier with min amount is required",
+			items: []OrderItemRequestModel{{ProductID: "soup", Amount: 1}},
+			want:  []OrderViolationCode{ViolationModifierRequired},
+		},
+		{
+			name:  "fractional modifier amount",
+			items: []OrderItemRequestModel{{ProductID: "soup", Amount: 1, Modifiers: []OrderItemModifierRequestModel{sauce("bread", 2.5)}}},
+			want:  []OrderViolationCode{ViolationModifierAmount},
+		},
+		{
+			name:  "default size and group modifier",
+			items: []OrderItemRequestModel{{ProductID: "pizza", Amount: 1, Modifiers: []OrderItemModifierRequestModel{sauce("ketchup", 1)}}},
+		},
+		{
+			name:  "invalid size",
+			items: []OrderItemRequestModel{{ProductID: "pizza", Amount: 1, ProductSizeID: &xl}},
+			want:  []OrderViolationCode{ViolationSizeInvalid},
+		},
+		{
+			name:  "required group missing",
+			items: []OrderItemRequestModel{{ProductID: "pizza", Amount: 1, ProductSizeID: &large}},
+			want:  []OrderViolationCode{ViolationGroupRequired},
+		},
+		{
+			name: "group amount exceeded",
+			items: []OrderItemRequestModel{{ProductID: "pizza", Amount: 1, Modifiers: []OrderItemModifierRequestModel{
+				sauce("ketchup", 1), sauce("mayo", 1), sauce("ketchup", 1),
+			}}},
+			want: []OrderViolationCode{ViolationGroupAmount},
+		},
+		{
+			name:  "foreign modifier",
+			items: []OrderItemRequestModel{{ProductID: "soup", Amount: 1, Modifiers: []OrderItemModifierRequestModel{sauce("bread", 1), sauce("mayo", 1)}}},
+			want:  []OrderViolationCode{ViolationModifierNotFound},
+		},
+		{
+			name: "compound components are checked",
+			items: []OrderItemRequestModel{{
+				Type:               OrderItemTypeCompound,
+				Amount:             1,
+				ProductSizeID:      &large,
+				PrimaryComponent:   &OrderItemComponentRequestModel{ProductID: "pizza", Modifiers: []OrderItemModifierRequestModel{sauce("mayo", 1)}},
+				SecondaryComponent: &OrderItemComponentRequestModel{ProductID: "tea"},
+			}},
+			want: []OrderViolationCode{ViolationProductNotFound},
+		},
+	}
+	v := NewNomenclatureValidator(testNomenclature())
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var got []OrderViolationCode
+			for _, violation := range v.Validate(&OrderRequestModel{Items: tt.items}) {
+				got = append(got, violation.Code)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("Validate() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
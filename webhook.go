@@ -1,12 +1,15 @@
 package goiikoapi
 
 import (
+	"context"
 	"encoding/json"
-	"errors"
+	"sort"
 )
 
 // WebHook содержит утилиты для работы с webhook'ами
-type WebHook struct{}
+type WebHook struct {
+	client *Client
+}
 
 // Проверяем, что WebHook реализует IWebHook
 var _ IWebHook = (*WebHook)(nil)
@@ -24,10 +27,97 @@ func (wh *WebHook) ParseWebhookOrder(data []map[string]any) ([]WebHookDeliveryOr
 	return result, nil
 }
 
-// ParseWebhookReserve реплицирует WebHook.parse_webhook_reserve (заглушка)
-func (wh *WebHook) ParseWebhookReserve(data []map[string]any) ([]WebHookDeliveryOrderEventInfoModel, error) {
-	// В Python версии это FutureWarning, поэтому возвращаем ошибку
-	return nil, errors.New("parse_webhook_reserve: в разработке")
+// ParseWebhookReserve разбирает события резервов (ReserveUpdate, ReserveError)
+func (wh *WebHook) ParseWebhookReserve(data []map[string]any) ([]WebHookReserveEventInfoModel, error) {
+	var result []WebHookReserveEventInfoModel
+	for _, item := range data {
+		var ev WebHookReserveEventInfoModel
+		jsonData, err := json.Marshal(item)
+		if err != nil { return nil, err }
+		if err := json.Unmarshal(jsonData, &ev); err != nil { return nil, err }
+		result = append(result, ev)
+	}
+	return result, nil
+}
+
+// GetSettings реплицирует WebHook.webhooks_settings
+func (wh *WebHook) GetSettings(ctx context.Context, organizationID string) (*WebHookSettingsModel, *CustomErrorModel, error) {
+	data := map[string]any{"organizationId": organizationID}
+	var out WebHookSettingsModel
+	if cerr, err := wh.client.query(ctx, "/api/1/webhooks/settings", data, &out); cerr != nil || err != nil {
+		return nil, cerr, err
+	}
+	return &out, nil, nil
+}
+
+// UpdateSettings реплицирует WebHook.update_settings
+func (wh *WebHook) UpdateSettings(ctx context.Context, organizationID string, settings WebHookSettingsRequestModel) (*BaseResponseModel, *CustomErrorModel, error) {
+	data, err := requestData(settings, map[string]any{"organizationId": organizationID})
+	if err != nil { return nil, nil, err }
+	return wh.client.command(ctx, "/api/1/webhooks/update_settings", organizationID, data)
+}
+
+// EnsureSettings приводит настройки webhook всех организаций клиента к desired.
+// Организации, где настройки уже совпадают, не обновляются, поэтому вызов можно повторять.
+// Если список организаций еще не загружен, вызывается Organizations.
+func (wh *WebHook) EnsureSettings(ctx context.Context, desired WebHookSettingsRequestModel) ([]WebHookEnsureResult, error) {
+	orgIDs := wh.client.organizationIDs()
+	if len(orgIDs) == 0 {
+		orgs, cerr, err := wh.client.Organizations(ctx, nil, nil, nil)
+		if err == nil { err = cerr.Err() }
+		if err != nil { return nil, err }
+		orgIDs = orgs.ListIDs()
+	}
+	results := make([]WebHookEnsureResult, 0, len(orgIDs))
+	for _, orgID := range orgIDs {
+		res := WebHookEnsureResult{OrganizationID: orgID}
+		current, cerr, err := wh.GetSettings(ctx, orgID)
+		if err == nil { err = cerr.Err() }
+		if err == nil && !webhookSettingsEqual(current, desired) {
+			_, cerr, err = wh.UpdateSettings(ctx, orgID, desired)
+			if err == nil { err = cerr.Err() }
+			res.Updated = err == nil
+		}
+		res.Err = err
+		results = append(results, res)
+		if ctx.Err() != nil { return results, ctx.Err() }
+	}
+	return results, nil
+}
+
+// webhookSettingsEqual сравнивает текущие настройки с желаемыми без учета порядка статусов
+func webhookSettingsEqual(current *WebHookSettingsModel, desired WebHookSettingsRequestModel) bool {
+	if current.WebHooksURI == nil || *current.WebHooksURI != desired.WebHooksURI {
+		return false
+	}
+	if desired.AuthToken != nil && (current.AuthToken == nil || *current.AuthToken != *desired.AuthToken) {
+		return false
+	}
+	a, _ := json.Marshal(normalizeWebhookFilter(current.WebHooksFilter))
+	b, _ := json.Marshal(normalizeWebhookFilter(desired.WebHooksFilter))
+	return string(a) == string(b)
+}
+
+func normalizeWebhookFilter(f *WebHookFilterModel) WebHookFilterModel {
+	if f == nil {
+		return WebHookFilterModel{}
+	}
+	out := *f
+	out.DeliveryOrderFilter = normalizeOrderFilter(f.DeliveryOrderFilter)
+	out.TableOrderFilter = normalizeOrderFilter(f.TableOrderFilter)
+	return out
+}
+
+func normalizeOrderFilter(f *WebHookOrderFilterModel) *WebHookOrderFilterModel {
+	if f == nil {
+		return nil
+	}
+	out := WebHookOrderFilterModel{Errors: f.Errors}
+	out.OrderStatuses = append(out.OrderStatuses, f.OrderStatuses...)
+	out.ItemStatuses = append(out.ItemStatuses, f.ItemStatuses...)
+	sort.Strings(out.OrderStatuses)
+	sort.Strings(out.ItemStatuses)
+	return &out
 }
 
 // Глобальные функции для совместимости с Python API
@@ -37,8 +127,8 @@ func ParseWebhookOrder(data []map[string]any) ([]WebHookDeliveryOrderEventInfoMo
 	return wh.ParseWebhookOrder(data)
 }
 
-// ParseWebhookReserve реплицирует WebHook.parse_webhook_reserve (заглушка)
-func ParseWebhookReserve(data []map[string]any) ([]WebHookDeliveryOrderEventInfoModel, error) {
+// ParseWebhookReserve реплицирует WebHook.parse_webhook_reserve
+func ParseWebhookReserve(data []map[string]any) ([]WebHookReserveEventInfoModel, error) {
 	wh := &WebHook{}
 	return wh.ParseWebhookReserve(data)
 }
@@ -0,0 +1,308 @@
+package goiikoapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// Типы событий webhook iiko
+const (
+	WebHookEventDeliveryOrderUpdate = "DeliveryOrderUpdate"
+	WebHookEventDeliveryOrderError  = "DeliveryOrderError"
+	WebHookEventTableOrderUpdate    = "TableOrderUpdate"
+	WebHookEventTableOrderError     = "TableOrderError"
+	WebHookEventReserveUpdate       = "ReserveUpdate"
+	WebHookEventReserveError        = "ReserveError"
+	WebHookEventStopListUpdate      = "StopListUpdate"
+	WebHookEventPersonalShift       = "PersonalShift"
+	WebHookEventNomenclatureUpdate  = "NomenclatureUpdate"
+)
+
+// WebHookEventMeta общие поля события webhook
+type WebHookEventMeta struct {
+	EventType      string
+	EventTime      *string
+	OrganizationID string
+	CorrelationID  string
+}
+
+// WebHookEvent событие webhook; конкретный тип определяется по eventType:
+//
+//	switch ev := ev.(type) {
+//	case *goiikoapi.DeliveryOrderUpdateEvent: ...
+//	case *goiikoapi.StopListUpdateEvent: ...
+//	case *goiikoapi.UnknownEvent: // ev.Raw
+//	}
+type WebHookEvent interface {
+	// Type eventType события
+	Type() string
+	Meta() WebHookEventMeta
+}
+
+// DeliveryOrderUpdateEvent DeliveryOrderUpdate: создание или изменение заказа доставки
+type DeliveryOrderUpdateEvent struct {
+	WebHookDeliveryOrderEventInfoModel
+}
+
+// DeliveryOrderErrorEvent DeliveryOrderError: ошибка создания заказа доставки (EventInfo.ErrorInfo)
+type DeliveryOrderErrorEvent struct {
+	WebHookDeliveryOrderEventInfoModel
+}
+
+// TableOrderUpdateEvent TableOrderUpdate: создание или изменение заказа на стол
+type TableOrderUpdateEvent struct {
+	WebHookTableOrderEventInfoModel
+}
+
+// TableOrderErrorEvent TableOrderError: ошибка создания заказа на стол
+type TableOrderErrorEvent struct {
+	WebHookTableOrderEventInfoModel
+}
+
+// ReserveUpdateEvent ReserveUpdate: создание или изменение резерва
+type ReserveUpdateEvent struct {
+	WebHookReserveEventInfoModel
+}
+
+// ReserveErrorEvent ReserveError: ошибка создания резерва
+type ReserveErrorEvent struct {
+	WebHookReserveEventInfoModel
+}
+
+// StopListUpdateEvent StopListUpdate: изменение стоп-листов терминальных групп
+type StopListUpdateEvent struct {
+	WebHookStopListUpdateEventInfoModel
+}
+
+// PersonalShiftEvent PersonalShift: открытие или закрытие личной смены сотрудника
+type PersonalShiftEvent struct {
+	WebHookPersonalShiftEventInfoModel
+}
+
+// NomenclatureUpdateEvent NomenclatureUpdate: изменение номенклатуры
+type NomenclatureUpdateEvent struct {
+	WebHookNomenclatureUpdateEventInfoModel
+}
+
+// UnknownEvent событие неизвестного типа или событие, которое не удалось разобрать
+// в свой тип (тогда Err содержит ошибку разбора); Raw — исходный JSON события
+type UnknownEvent struct {
+	EventType      string          `json:"eventType"`
+	EventTime      *string         `json:"eventTime,omitempty"`
+	OrganizationID string          `json:"organizationId"`
+	CorrelationID  string          `json:"correlationId"`
+	Raw            json.RawMessage `json:"-"`
+	Err            error           `json:"-"`
+}
+
+func (e *DeliveryOrderUpdateEvent) Type() string { return WebHookEventDeliveryOrderUpdate }
+func (e *DeliveryOrderErrorEvent) Type() string  { return WebHookEventDeliveryOrderError }
+func (e *TableOrderUpdateEvent) Type() string    { return WebHookEventTableOrderUpdate }
+func (e *TableOrderErrorEvent) Type() string     { return WebHookEventTableOrderError }
+func (e *ReserveUpdateEvent) Type() string       { return WebHookEventReserveUpdate }
+func (e *ReserveErrorEvent) Type() string        { return WebHookEventReserveError }
+func (e *StopListUpdateEvent) Type() string      { return WebHookEventStopListUpdate }
+func (e *PersonalShiftEvent) Type() string       { return WebHookEventPersonalShift }
+func (e *NomenclatureUpdateEvent) Type() string  { return WebHookEventNomenclatureUpdate }
+func (e *UnknownEvent) Type() string             { return e.EventType }
+
+func (e *DeliveryOrderUpdateEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *DeliveryOrderErrorEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *TableOrderUpdateEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *TableOrderErrorEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *ReserveUpdateEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *ReserveErrorEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *StopListUpdateEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *PersonalShiftEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *NomenclatureUpdateEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.Type(), e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+func (e *UnknownEvent) Meta() WebHookEventMeta {
+	return WebHookEventMeta{e.EventType, e.EventTime, e.OrganizationID, e.CorrelationID}
+}
+
+// MarshalJSON всегда записывает eventType своего типа, даже если поле EventType не заполнено
+func (e *DeliveryOrderUpdateEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookDeliveryOrderEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *DeliveryOrderErrorEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookDeliveryOrderEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *TableOrderUpdateEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookTableOrderEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *TableOrderErrorEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookTableOrderEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *ReserveUpdateEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookReserveEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *ReserveErrorEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookReserveEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *StopListUpdateEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookStopListUpdateEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *PersonalShiftEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookPersonalShiftEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+func (e *NomenclatureUpdateEvent) MarshalJSON() ([]byte, error) {
+	m := e.WebHookNomenclatureUpdateEventInfoModel
+	m.EventType = e.Type()
+	return json.Marshal(m)
+}
+
+// MarshalJSON возвращает исходный JSON события, если он сохранен
+func (e *UnknownEvent) MarshalJSON() ([]byte, error) {
+	if len(e.Raw) > 0 {
+		return e.Raw, nil
+	}
+	type plain UnknownEvent
+	return json.Marshal((*plain)(e))
+}
+
+// newWebHookEvent пустое событие для eventType; неизвестный тип — *UnknownEvent
+func newWebHookEvent(eventType string) WebHookEvent {
+	switch eventType {
+	case WebHookEventDeliveryOrderUpdate:
+		return &DeliveryOrderUpdateEvent{}
+	case WebHookEventDeliveryOrderError:
+		return &DeliveryOrderErrorEvent{}
+	case WebHookEventTableOrderUpdate:
+		return &TableOrderUpdateEvent{}
+	case WebHookEventTableOrderError:
+		return &TableOrderErrorEvent{}
+	case WebHookEventReserveUpdate:
+		return &ReserveUpdateEvent{}
+	case WebHookEventReserveError:
+		return &ReserveErrorEvent{}
+	case WebHookEventStopListUpdate:
+		return &StopListUpdateEvent{}
+	case WebHookEventPersonalShift:
+		return &PersonalShiftEvent{}
+	case WebHookEventNomenclatureUpdate:
+		return &NomenclatureUpdateEvent{}
+	}
+	return &UnknownEvent{}
+}
+
+// DecodeWebHookEvent разбирает одно событие в тип, соответствующий его eventType.
+// Если событие не разбирается в свой тип, возвращается *UnknownEvent с исходным JSON
+// и та же ошибка, что в UnknownEvent.Err.
+func DecodeWebHookEvent(data []byte) (WebHookEvent, error) {
+	var head struct {
+		EventType string `json:"eventType"`
+	}
+	if err := json.Unmarshal(data, &head); err != nil {
+		return unknownWebHookEvent(data, "", fmt.Errorf("webhook: decode event: %w", err))
+	}
+	ev := newWebHookEvent(head.EventType)
+	if err := json.Unmarshal(data, ev); err != nil {
+		return unknownWebHookEvent(data, head.EventType, fmt.Errorf("webhook: decode %s: %w", head.EventType, err))
+	}
+	if u, ok := ev.(*UnknownEvent); ok {
+		u.Raw = append(json.RawMessage(nil), data...)
+	}
+	return ev, nil
+}
+
+// unknownWebHookEvent сохраняет неразобранное событие; общие поля заполняются, если их удалось прочитать
+func unknownWebHookEvent(data []byte, eventType string, err error) (WebHookEvent, error) {
+	u := &UnknownEvent{}
+	if json.Unmarshal(data, u) != nil {
+		u = &UnknownEvent{EventType: eventType}
+	}
+	u.Raw, u.Err = append(json.RawMessage(nil), data...), err
+	return u, err
+}
+
+// splitWebHookBody делит тело запроса iiko на события: массив событий или одно событие
+func splitWebHookBody(body []byte) ([]json.RawMessage, error) {
+	body = bytes.TrimSpace(body)
+	if len(body) > 0 && body[0] == '{' {
+		if !json.Valid(body) {
+			return nil, errors.New("webhook: decode events: invalid JSON")
+		}
+		return []json.RawMessage{body}, nil
+	}
+	var raws []json.RawMessage
+	if err := json.Unmarshal(body, &raws); err != nil {
+		return nil, fmt.Errorf("webhook: decode events: %w", err)
+	}
+	return raws, nil
+}
+
+// DecodeWebHookEvents разбирает тело запроса iiko: массив событий или одно событие.
+// Ошибка возвращается, только если тело не является JSON-массивом или объектом; события,
+// не разобранные в свой тип, возвращаются как *UnknownEvent с заполненным Err.
+func DecodeWebHookEvents(body []byte) ([]WebHookEvent, error) {
+	raws, err := splitWebHookBody(body)
+	if err != nil {
+		return nil, err
+	}
+	events := make([]WebHookEvent, 0, len(raws))
+	for _, raw := range raws {
+		ev, _ := DecodeWebHookEvent(raw)
+		events = append(events, ev)
+	}
+	return events, nil
+}
+
+// EncodeWebHookEvents кодирует события в тело запроса iiko; DecodeWebHookEvents восстанавливает их
+func EncodeWebHookEvents(events []WebHookEvent) ([]byte, error) {
+	if events == nil {
+		events = []WebHookEvent{}
+	}
+	return json.Marshal(events)
+}
@@ -0,0 +1,245 @@
+package goiikoapi
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+	"sync"
+)
+
+// defaultWebHookMaxBodySize максимальный размер тела запроса по умолчанию
+const defaultWebHookMaxBodySize = 1 << 20
+
+// defaultWebHookQueueSize число принятых запросов, ожидающих обработчиков, по умолчанию
+const defaultWebHookQueueSize = 256
+
+// WebHookHandlerConfig настройки WebHookHandler
+type WebHookHandlerConfig struct {
+	// AuthToken токен из настроек webhook (WebHookSettingsRequestModel.AuthToken);
+	// пустой — заголовок Authorization не проверяется
+	AuthToken string
+	// MaxBodySize максимальный размер тела запроса, по умолчанию 1 МБ
+	MaxBodySize int64
+	// QueueSize сколько принятых запросов может ждать обработчиков, по умолчанию 256;
+	// при переполнении отвечаем 503, и iiko повторит доставку
+	QueueSize int
+	// Persist сохраняет события в исходном JSON до разбора и до ответа iiko (например,
+	// WebHookQueue.Enqueue); при ошибке отвечаем 503, и iiko повторит доставку
+	Persist func([]json.RawMessage) error
+	// OnError вызывается при ошибке разбора запроса или события и при панике в обработчике события
+	OnError func(error)
+}
+
+// WebHookHandler http.Handler для приема webhook'ов iiko. Тело делится на события, которые передаются
+// в Persist в исходном виде и затем разбираются в типы; ответ 200 отправляется до вызова
+// обработчиков: они выполняются в одной фоновой горутине строго в порядке приема запросов,
+// поэтому события одного заказа приходят в обработчики по порядку.
+// Обработчики регистрируются до запуска сервера.
+//
+//	h := goiikoapi.NewWebHookHandler(goiikoapi.WebHookHandlerConfig{AuthToken: "secret"})
+//	h.OnDeliveryOrderUpdate(func(ev goiikoapi.WebHookDeliveryOrderEventInfoModel) { ... })
+//	http.Handle("/iiko/webhook", h)
+type WebHookHandler struct {
+	cfg   WebHookHandlerConfig
+	wg    sync.WaitGroup
+	once  sync.Once
+	queue chan []json.RawMessage
+
+	onDeliveryOrder func(WebHookDeliveryOrderEventInfoModel)
+	onTableOrder    func(WebHookTableOrderEventInfoModel)
+	onReserve       func(WebHookReserveEventInfoModel)
+	onStopList      func(WebHookStopListUpdateEventInfoModel)
+	onPersonalShift func(WebHookPersonalShiftEventInfoModel)
+	onEvent         func(WebHookEvent)
+}
+
+// Проверяем, что WebHookHandler реализует http.Handler
+var _ http.Handler = (*WebHookHandler)(nil)
+
+// NewWebHookHandler создает обработчик webhook'ов
+func NewWebHookHandler(cfg WebHookHandlerConfig) *WebHookHandler {
+	if cfg.MaxBodySize <= 0 {
+		cfg.MaxBodySize = defaultWebHookMaxBodySize
+	}
+	if cfg.QueueSize <= 0 {
+		cfg.QueueSize = defaultWebHookQueueSize
+	}
+	return &WebHookHandler{cfg: cfg, queue: make(chan []json.RawMessage, cfg.QueueSize)}
+}
+
+// OnDeliveryOrderUpdate регистрирует обработчик DeliveryOrderUpdate и DeliveryOrderError
+func (h *WebHookHandler) OnDeliveryOrderUpdate(fn func(WebHookDeliveryOrderEventInfoModel)) {
+	h.onDeliveryOrder = fn
+}
+
+// OnTableOrderUpdate регистрирует обработчик TableOrderUpdate и TableOrderError
+func (h *WebHookHandler) OnTableOrderUpdate(fn func(WebHookTableOrderEventInfoModel)) {
+	h.onTableOrder = fn
+}
+
+// OnReserveUpdate регистрирует обработчик ReserveUpdate и ReserveError
+func (h *WebHookHandler) OnReserveUpdate(fn func(WebHookReserveEventInfoModel)) {
+	h.onReserve = fn
+}
+
+// OnStopListUpdate регистрирует обработчик StopListUpdate
+func (h *WebHookHandler) OnStopListUpdate(fn func(WebHookStopListUpdateEventInfoModel)) {
+	h.onStopList = fn
+}
+
+// OnPersonalShift регистрирует обработчик PersonalShift
+func (h *WebHookHandler) OnPersonalShift(fn func(WebHookPersonalShiftEventInfoModel)) {
+	h.onPersonalShift = fn
+}
+
+// OnEvent регистрирует обработчик всех событий, включая NomenclatureUpdate и *UnknownEvent
+// (в том числе события, не разобранные в свой тип). Вызывается перед типизированным обработчиком.
+func (h *WebHookHandler) OnEvent(fn func(WebHookEvent)) {
+	h.onEvent = fn
+}
+
+// Wait ожидает завершения запущенных обработчиков событий (для graceful shutdown)
+func (h *WebHookHandler) Wait() {
+	h.wg.Wait()
+}
+
+// ServeHTTP проверяет токен, разбирает события и отвечает 200 до их обработки
+func (h *WebHookHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodPost {
+		w.Header().Set("Allow", http.MethodPost)
+		http.Error(w, http.StatusText(http.StatusMethodNotAllowed), http.StatusMethodNotAllowed)
+		return
+	}
+	if !h.authorized(r) {
+		http.Error(w, http.StatusText(http.StatusUnauthorized), http.StatusUnauthorized)
+		return
+	}
+	body, err := io.ReadAll(http.MaxBytesReader(w, r.Body, h.cfg.MaxBodySize))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			http.Error(w, http.StatusText(http.StatusRequestEntityTooLarge), http.StatusRequestEntityTooLarge)
+		} else {
+			http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		}
+		h.reportError(fmt.Errorf("webhook: read body: %w", err))
+		return
+	}
+	raws, err := splitWebHookBody(body)
+	if err != nil {
+		http.Error(w, http.StatusText(http.StatusBadRequest), http.StatusBadRequest)
+		h.reportError(fmt.Errorf("webhook: %w", err))
+		return
+	}
+	if h.cfg.Persist != nil {
+		if err := h.cfg.Persist(raws); err != nil {
+			http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+			h.reportError(fmt.Errorf("webhook: persist: %w", err))
+			return
+		}
+	}
+	h.once.Do(func() { go h.worker() })
+	h.wg.Add(1)
+	select {
+	case h.queue <- raws:
+	default:
+		h.wg.Done()
+		http.Error(w, http.StatusText(http.StatusServiceUnavailable), http.StatusServiceUnavailable)
+		h.reportError(errors.New("webhook: handler queue is full"))
+		return
+	}
+	w.WriteHeader(http.StatusOK)
+}
+
+// worker обрабатывает принятые запросы по одному, сохраняя порядок событий
+func (h *WebHookHandler) worker() {
+	for raws := range h.queue {
+		for _, raw := range raws {
+			ev, _ := DecodeWebHookEvent(raw)
+			h.dispatch(ev)
+		}
+		h.wg.Done()
+	}
+}
+
+// authorized сравнивает заголовок Authorization с AuthToken; префикс Bearer допускается
+func (h *WebHookHandler) authorized(r *http.Request) bool {
+	if h.cfg.AuthToken == "" {
+		return true
+	}
+	token := strings.TrimSpace(r.Header.Get("Authorization"))
+	if len(token) > 7 && strings.EqualFold(token[:7], "Bearer ") {
+		token = strings.TrimSpace(token[7:])
+	}
+	return subtle.ConstantTimeCompare([]byte(token), []byte(h.cfg.AuthToken)) == 1
+}
+
+// dispatch вызывает обработчики события; паника обработчика передается в OnError
+// и не мешает вызову остальных обработчиков
+func (h *WebHookHandler) dispatch(ev WebHookEvent) {
+	if u, ok := ev.(*UnknownEvent); ok && u.Err != nil {
+		h.reportError(u.Err)
+	}
+	if h.onEvent != nil {
+		h.call(ev, func() { h.onEvent(ev) })
+	}
+	h.call(ev, func() { h.dispatchTyped(ev) })
+}
+
+// call вызывает fn, перехватывая панику обработчика
+func (h *WebHookHandler) call(ev WebHookEvent, fn func()) {
+	defer func() {
+		if p := recover(); p != nil {
+			h.reportError(fmt.Errorf("webhook: %s handler panic: %v", ev.Type(), p))
+		}
+	}()
+	fn()
+}
+
+// dispatchTyped вызывает типизированный обработчик события
+func (h *WebHookHandler) dispatchTyped(ev WebHookEvent) {
+	switch ev := ev.(type) {
+	case *DeliveryOrderUpdateEvent:
+		if h.onDeliveryOrder != nil {
+			h.onDeliveryOrder(ev.WebHookDeliveryOrderEventInfoModel)
+		}
+	case *DeliveryOrderErrorEvent:
+		if h.onDeliveryOrder != nil {
+			h.onDeliveryOrder(ev.WebHookDeliveryOrderEventInfoModel)
+		}
+	case *TableOrderUpdateEvent:
+		if h.onTableOrder != nil {
+			h.onTableOrder(ev.WebHookTableOrderEventInfoModel)
+		}
+	case *TableOrderErrorEvent:
+		if h.onTableOrder != nil {
+			h.onTableOrder(ev.WebHookTableOrderEventInfoModel)
+		}
+	case *ReserveUpdateEvent:
+		if h.onReserve != nil {
+			h.onReserve(ev.WebHookReserveEventInfoModel)
+		}
+	case *ReserveErrorEvent:
+		if h.onReserve != nil {
+			h.onReserve(ev.WebHookReserveEventInfoModel)
+		}
+	case *StopListUpdateEvent:
+		if h.onStopList != nil {
+			h.onStopList(ev.WebHookStopListUpdateEventInfoModel)
+		}
+	case *PersonalShiftEvent:
+		if h.onPersonalShift != nil {
+			h.onPersonalShift(ev.WebHookPersonalShiftEventInfoModel)
+		}
+	}
+}
+
+func (h *WebHookHandler) reportError(err error) {
+	if h.cfg.OnError != nil {
+		h.cfg.OnError(err)
+	}
+}
@@ -0,0 +1,579 @@
+package goiikoapi
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// Статусы записей очереди webhook'ов
+const (
+	WebHookRecordPending = "pending"
+	WebHookRecordDone    = "done"
+	WebHookRecordDead    = "dead"
+)
+
+// ErrWebHookRecordNotFound запись с указанным ключом отсутствует в хранилище
+var ErrWebHookRecordNotFound = errors.New("webhook: record not found")
+
+// WebHookRecord сохраненное событие webhook
+type WebHookRecord struct {
+	// Key ключ дедупликации: correlationId, id заказа и timestamp события
+	Key string `json:"key"`
+	// OrderID id заказа или резерва; пустой для событий без заказа (StopListUpdate, PersonalShift и т.п.)
+	OrderID string `json:"orderId,omitempty"`
+	// Timestamp EventInfo.Timestamp, по нему упорядочиваются события одного заказа
+	Timestamp     int64           `json:"timestamp,omitempty"`
+	EventType     string          `json:"eventType"`
+	Event         json.RawMessage `json:"event"`
+	Status        string          `json:"status"`
+	Attempts      int             `json:"attempts,omitempty"`
+	LastError     string          `json:"lastError,omitempty"`
+	ReceivedAt    time.Time       `json:"receivedAt"`
+	NextAttemptAt time.Time       `json:"nextAttemptAt,omitempty"`
+	// Requeued событие возвращено из dead-letter через Requeue и доставляется без проверки порядка
+	Requeued bool `json:"requeued,omitempty"`
+}
+
+// WebHookStore хранилище записей очереди webhook'ов
+type WebHookStore interface {
+	// Put добавляет запись; false — запись с таким Key уже есть (дубликат)
+	Put(rec WebHookRecord) (bool, error)
+	// Update заменяет запись с тем же Key
+	Update(rec WebHookRecord) error
+	Delete(key string) error
+	// List записи со статусом status в порядке получения
+	List(status string) ([]WebHookRecord, error)
+}
+
+// MemoryWebHookStore хранилище в памяти; записи теряются при перезапуске
+type MemoryWebHookStore struct {
+	mu      sync.RWMutex
+	records map[string]WebHookRecord
+}
+
+// Проверяем, что хранилища реализуют WebHookStore
+var (
+	_ WebHookStore = (*MemoryWebHookStore)(nil)
+	_ WebHookStore = (*FileWebHookStore)(nil)
+)
+
+// NewMemoryWebHookStore создает хранилище в памяти
+func NewMemoryWebHookStore() *MemoryWebHookStore {
+	return &MemoryWebHookStore{records: map[string]WebHookRecord{}}
+}
+
+func (s *MemoryWebHookStore) Put(rec WebHookRecord) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[rec.Key]; ok {
+		return false, nil
+	}
+	s.records[rec.Key] = rec
+	return true, nil
+}
+
+func (s *MemoryWebHookStore) Update(rec WebHookRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.records[rec.Key]; !ok {
+		return ErrWebHookRecordNotFound
+	}
+	s.records[rec.Key] = rec
+	return nil
+}
+
+func (s *MemoryWebHookStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.records, key)
+	return nil
+}
+
+func (s *MemoryWebHookStore) List(status string) ([]WebHookRecord, error) {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	var out []WebHookRecord
+	for _, rec := range s.records {
+		if rec.Status == status {
+			out = append(out, rec)
+		}
+	}
+	sortByReceived(out)
+	return out, nil
+}
+
+func (s *MemoryWebHookStore) all() []WebHookRecord {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	out := make([]WebHookRecord, 0, len(s.records))
+	for _, rec := range s.records {
+		out = append(out, rec)
+	}
+	sortByReceived(out)
+	return out
+}
+
+func sortByReceived(recs []WebHookRecord) {
+	sort.SliceStable(recs, func(i, j int) bool {
+		if !recs[i].ReceivedAt.Equal(recs[j].ReceivedAt) {
+			return recs[i].ReceivedAt.Before(recs[j].ReceivedAt)
+		}
+		return recs[i].Key < recs[j].Key
+	})
+}
+
+// FileWebHookStore хранилище в JSON-файле. Файл целиком перезаписывается (через временный файл
+// и rename) при каждом изменении, поэтому подходит для сотен и тысяч записей; старые
+// подтвержденные записи удаляются через WebHookQueue.Prune.
+type FileWebHookStore struct {
+	mu   sync.Mutex
+	path string
+	mem  *MemoryWebHookStore
+}
+
+// NewFileWebHookStore открывает хранилище path; отсутствующий файл создается при первой записи
+func NewFileWebHookStore(path string) (*FileWebHookStore, error) {
+	s := &FileWebHookStore{path: path, mem: NewMemoryWebHookStore()}
+	b, err := os.ReadFile(path)
+	if errors.Is(err, os.ErrNotExist) {
+		return s, nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	var recs []WebHookRecord
+	if len(b) > 0 {
+		if err := json.Unmarshal(b, &recs); err != nil {
+			return nil, fmt.Errorf("webhook: load %s: %w", path, err)
+		}
+	}
+	for _, rec := range recs {
+		s.mem.records[rec.Key] = rec
+	}
+	return s, nil
+}
+
+func (s *FileWebHookStore) Put(rec WebHookRecord) (bool, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	added, _ := s.mem.Put(rec)
+	if !added {
+		return false, nil
+	}
+	if err := s.save(); err != nil {
+		_ = s.mem.Delete(rec.Key)
+		return false, err
+	}
+	return true, nil
+}
+
+func (s *FileWebHookStore) Update(rec WebHookRecord) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if err := s.mem.Update(rec); err != nil {
+		return err
+	}
+	return s.save()
+}
+
+func (s *FileWebHookStore) Delete(key string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	_ = s.mem.Delete(key)
+	return s.save()
+}
+
+func (s *FileWebHookStore) List(status string) ([]WebHookRecord, error) {
+	return s.mem.List(status)
+}
+
+// save атомарно записывает все записи в файл
+func (s *FileWebHookStore) save() error {
+	b, err := json.Marshal(s.mem.all())
+	if err != nil {
+		return err
+	}
+	tmp := s.path + ".tmp"
+	f, err := os.OpenFile(tmp, os.O_WRONLY|os.O_CREATE|os.O_TRUNC, 0o600)
+	if err != nil {
+		return err
+	}
+	if _, err := f.Write(b); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Sync(); err != nil {
+		f.Close()
+		return err
+	}
+	if err := f.Close(); err != nil {
+		return err
+	}
+	if err := os.Rename(tmp, s.path); err != nil {
+		return err
+	}
+	// fsync каталога, чтобы переименование пережило сбой питания
+	dir, err := os.Open(filepath.Dir(s.path))
+	if err != nil {
+		return err
+	}
+	defer dir.Close()
+	return dir.Sync()
+}
+
+// WebHookQueueConfig настройки WebHookQueue
+type WebHookQueueConfig struct {
+	// ReorderWindow сколько событие ждет более ранних событий своего заказа, по умолчанию 2 секунды
+	ReorderWindow time.Duration
+	// PollInterval период обработки очереди в Run, по умолчанию 1 секунда
+	PollInterval time.Duration
+	// MaxAttempts число попыток доставки до перевода в dead-letter, по умолчанию 5
+	MaxAttempts int
+	// RetryDelay задержка перед повтором, удваивается с каждой попыткой, но не превышает
+	// maxWebHookRetryDelay; по умолчанию 5 секунд
+	RetryDelay time.Duration
+	// OnError вызывается при ошибке хранилища или обработчика; обработка продолжается
+	OnError func(error)
+}
+
+// WebHookQueue надежная очередь событий webhook поверх WebHookStore. События сохраняются в исходном JSON
+// до ответа iiko (WebHookHandlerConfig.Persist) и переживают перезапуск; повторные доставки
+// отбрасываются по ключу correlationId + id заказа + timestamp; события одного заказа
+// передаются по возрастанию EventInfo.Timestamp, опоздавшие попадают в dead-letter. Доставка at-least-once:
+// событие подтверждается, только когда consumer вернул nil, после MaxAttempts неудач оно
+// попадает в dead-letter (DeadLetters, Requeue).
+//
+//	q := goiikoapi.NewWebHookQueue(store, goiikoapi.WebHookQueueConfig{})
+//	h := goiikoapi.NewWebHookHandler(goiikoapi.WebHookHandlerConfig{AuthToken: "secret", Persist: q.Enqueue})
+//	go q.Run(ctx, func(ctx context.Context, ev goiikoapi.WebHookEvent) error { ... })
+type WebHookQueue struct {
+	store WebHookStore
+	cfg   WebHookQueueConfig
+
+	// passMu сериализует проходы ProcessPending, mu защищает last и переходы статусов;
+	// consumer вызывается без mu, поэтому может обращаться к очереди
+	passMu sync.Mutex
+	mu     sync.Mutex
+	last   map[string]int64
+}
+
+// NewWebHookQueue создает очередь поверх store
+func NewWebHookQueue(store WebHookStore, cfg WebHookQueueConfig) *WebHookQueue {
+	if cfg.ReorderWindow <= 0 {
+		cfg.ReorderWindow = 2 * time.Second
+	}
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = time.Second
+	}
+	if cfg.MaxAttempts <= 0 {
+		cfg.MaxAttempts = 5
+	}
+	if cfg.RetryDelay <= 0 {
+		cfg.RetryDelay = 5 * time.Second
+	}
+	return &WebHookQueue{store: store, cfg: cfg}
+}
+
+// Enqueue сохраняет события в исходном виде, до разбора в типы: событие, которое не удастся
+// разобрать, не теряется, а попадает в dead-letter при доставке. Дубликаты уже сохраненных
+// событий пропускаются.
+func (q *WebHookQueue) Enqueue(raws []json.RawMessage) error {
+	now := time.Now()
+	for _, raw := range raws {
+		key, eventType, orderID, ts := webHookEventKey(raw)
+		rec := WebHookRecord{
+			Key:        key,
+			OrderID:    orderID,
+			Timestamp:  ts,
+			EventType:  eventType,
+			Event:      append(json.RawMessage(nil), raw...),
+			Status:     WebHookRecordPending,
+			ReceivedAt: now,
+		}
+		if _, err := q.store.Put(rec); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Run обрабатывает очередь с интервалом PollInterval до отмены ctx. consumer подтверждает
+// событие, возвращая nil; ошибка или паника приводит к повтору.
+func (q *WebHookQueue) Run(ctx context.Context, consumer func(context.Context, WebHookEvent) error) error {
+	ticker := time.NewTicker(q.cfg.PollInterval)
+	defer ticker.Stop()
+	for {
+		if err := q.ProcessPending(ctx, consumer); err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			q.reportError(err)
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// ProcessPending выполняет один проход по очереди: доставляет готовые события.
+// Параллельные вызовы выполняются по очереди; Enqueue во время прохода не блокируется.
+func (q *WebHookQueue) ProcessPending(ctx context.Context, consumer func(context.Context, WebHookEvent) error) error {
+	q.passMu.Lock()
+	defer q.passMu.Unlock()
+	recs, err := q.pending()
+	if err != nil {
+		return err
+	}
+	var groups [][]WebHookRecord
+	byOrder := map[string]int{}
+	for _, rec := range recs {
+		if rec.OrderID == "" {
+			groups = append(groups, []WebHookRecord{rec})
+			continue
+		}
+		i, ok := byOrder[rec.OrderID]
+		if !ok {
+			i = len(groups)
+			byOrder[rec.OrderID] = i
+			groups = append(groups, nil)
+		}
+		groups[i] = append(groups[i], rec)
+	}
+	for _, group := range groups {
+		sort.SliceStable(group, func(i, j int) bool { return group[i].Timestamp < group[j].Timestamp })
+		for _, rec := range group {
+			if err := ctx.Err(); err != nil {
+				return err
+			}
+			// событие не готово: более поздние события заказа ждут его
+			if !q.deliver(ctx, rec, consumer) {
+				break
+			}
+		}
+	}
+	return nil
+}
+
+// pending восстанавливает last и возвращает снимок ожидающих событий
+func (q *WebHookQueue) pending() ([]WebHookRecord, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	if err := q.loadLast(); err != nil {
+		return nil, err
+	}
+	return q.store.List(WebHookRecordPending)
+}
+
+// deliver доставляет одно событие; false — событие осталось в очереди и следующие события заказа ждут
+func (q *WebHookQueue) deliver(ctx context.Context, rec WebHookRecord, consumer func(context.Context, WebHookEvent) error) bool {
+	now := time.Now()
+	if now.Sub(rec.ReceivedAt) < q.cfg.ReorderWindow || now.Before(rec.NextAttemptAt) {
+		return false
+	}
+	q.mu.Lock()
+	last, ok := q.last[rec.OrderID]
+	q.mu.Unlock()
+	if ok && rec.OrderID != "" && !rec.Requeued && rec.Timestamp < last {
+		// событие пришло после более нового события заказа: не теряем его, а откладываем в dead-letter
+		rec.Status = WebHookRecordDead
+		rec.LastError = fmt.Sprintf("stale: timestamp %d < %d", rec.Timestamp, last)
+		q.reportError(fmt.Errorf("webhook: deliver %s: %s", rec.Key, rec.LastError))
+		q.finish(rec)
+		return true
+	}
+	ev, err := DecodeWebHookEvent(rec.Event)
+	if err != nil {
+		// повтор не поможет: событие сразу уходит в dead-letter
+		rec.Status, rec.LastError = WebHookRecordDead, err.Error()
+		q.reportError(fmt.Errorf("webhook: deliver %s: %w", rec.Key, err))
+		q.finish(rec)
+		return true
+	}
+	err = consumeWebHookEvent(ctx, consumer, ev)
+	if err == nil {
+		rec.Status, rec.LastError = WebHookRecordDone, ""
+		q.finish(rec)
+		return true
+	}
+	rec.Attempts++
+	rec.LastError = err.Error()
+	q.reportError(fmt.Errorf("webhook: deliver %s: %w", rec.Key, err))
+	if rec.Attempts >= q.cfg.MaxAttempts {
+		rec.Status = WebHookRecordDead
+		q.finish(rec)
+		return true
+	}
+	rec.NextAttemptAt = now.Add(webHookRetryDelay(q.cfg.RetryDelay, rec.Attempts))
+	q.finish(rec)
+	return false
+}
+
+// finish сохраняет результат доставки и для подтвержденного события сдвигает last заказа
+func (q *WebHookQueue) finish(rec WebHookRecord) {
+	if rec.Status == WebHookRecordDone && rec.OrderID != "" {
+		q.mu.Lock()
+		if rec.Timestamp > q.last[rec.OrderID] {
+			q.last[rec.OrderID] = rec.Timestamp
+		}
+		q.mu.Unlock()
+	}
+	q.update(rec)
+}
+
+// consumeWebHookEvent вызывает consumer, превращая панику в ошибку
+func consumeWebHookEvent(ctx context.Context, consumer func(context.Context, WebHookEvent) error, ev WebHookEvent) (err error) {
+	defer func() {
+		if p := recover(); p != nil {
+			err = fmt.Errorf("panic: %v", p)
+		}
+	}()
+	return consumer(ctx, ev)
+}
+
+// loadLast восстанавливает timestamp последних доставленных событий заказов после перезапуска
+func (q *WebHookQueue) loadLast() error {
+	if q.last != nil {
+		return nil
+	}
+	done, err := q.store.List(WebHookRecordDone)
+	if err != nil {
+		return err
+	}
+	q.last = map[string]int64{}
+	for _, rec := range done {
+		if rec.OrderID != "" && rec.Timestamp > q.last[rec.OrderID] {
+			q.last[rec.OrderID] = rec.Timestamp
+		}
+	}
+	return nil
+}
+
+func (q *WebHookQueue) update(rec WebHookRecord) {
+	if err := q.store.Update(rec); err != nil {
+		q.reportError(fmt.Errorf("webhook: update %s: %w", rec.Key, err))
+	}
+}
+
+// DeadLetters события, не доставленные за MaxAttempts попыток
+func (q *WebHookQueue) DeadLetters() ([]WebHookRecord, error) {
+	return q.store.List(WebHookRecordDead)
+}
+
+// Requeue возвращает событие из dead-letter в очередь с обнуленным счетчиком попыток.
+// Возвращенное событие доставляется, даже если по заказу уже доставлены более новые события.
+func (q *WebHookQueue) Requeue(key string) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	dead, err := q.store.List(WebHookRecordDead)
+	if err != nil {
+		return err
+	}
+	for _, rec := range dead {
+		if rec.Key == key {
+			rec.Status, rec.Attempts, rec.NextAttemptAt, rec.Requeued = WebHookRecordPending, 0, time.Time{}, true
+			return q.store.Update(rec)
+		}
+	}
+	return ErrWebHookRecordNotFound
+}
+
+// Prune удаляет подтвержденные события старше olderThan. Пока событие хранится,
+// его повторная доставка из iiko отбрасывается как дубликат. Последнее подтвержденное
+// событие каждого заказа не удаляется: по нему после перезапуска восстанавливается порядок.
+func (q *WebHookQueue) Prune(olderThan time.Duration) (int, error) {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	done, err := q.store.List(WebHookRecordDone)
+	if err != nil {
+		return 0, err
+	}
+	newest := map[string]string{}
+	latest := map[string]int64{}
+	for _, rec := range done {
+		if _, ok := newest[rec.OrderID]; rec.OrderID != "" && (!ok || rec.Timestamp >= latest[rec.OrderID]) {
+			newest[rec.OrderID], latest[rec.OrderID] = rec.Key, rec.Timestamp
+		}
+	}
+	deadline := time.Now().Add(-olderThan)
+	n := 0
+	for _, rec := range done {
+		if rec.ReceivedAt.After(deadline) {
+			break
+		}
+		if rec.OrderID != "" && newest[rec.OrderID] == rec.Key {
+			continue
+		}
+		if err := q.store.Delete(rec.Key); err != nil {
+			return n, err
+		}
+		n++
+	}
+	return n, nil
+}
+
+func (q *WebHookQueue) reportError(err error) {
+	if q.cfg.OnError != nil {
+		q.cfg.OnError(err)
+	}
+}
+
+// maxWebHookRetryDelay верхняя граница задержки повторной доставки
+const maxWebHookRetryDelay = time.Hour
+
+// webHookRetryDelay задержка перед попыткой attempts+1: base*2^(attempts-1), не больше maxWebHookRetryDelay
+func webHookRetryDelay(base time.Duration, attempts int) time.Duration {
+	d := base
+	for i := 1; i < attempts && d < maxWebHookRetryDelay; i++ {
+		d *= 2
+	}
+	if d > maxWebHookRetryDelay {
+		d = maxWebHookRetryDelay
+	}
+	return d
+}
+
+// webHookEventKey ключ дедупликации, тип, id заказа и timestamp события. Заголовок события
+// разбирается без приведения к типу; если JSON не читается, ключ строится по хешу исходных байт.
+func webHookEventKey(raw json.RawMessage) (key, eventType, orderID string, timestamp int64) {
+	var head struct {
+		EventType     string  `json:"eventType"`
+		EventTime     *string `json:"eventTime"`
+		CorrelationID string  `json:"correlationId"`
+		EventInfo     *struct {
+			ID        string `json:"id"`
+			Timestamp int64  `json:"timestamp"`
+		} `json:"eventInfo"`
+	}
+	if err := json.Unmarshal(raw, &head); err != nil {
+		sum := sha256.Sum256(raw)
+		return "raw|" + hex.EncodeToString(sum[:]), "", "", 0
+	}
+	switch head.EventType {
+	case WebHookEventDeliveryOrderUpdate, WebHookEventDeliveryOrderError,
+		WebHookEventTableOrderUpdate, WebHookEventTableOrderError,
+		WebHookEventReserveUpdate, WebHookEventReserveError:
+		if head.EventInfo != nil {
+			orderID, timestamp = head.EventInfo.ID, head.EventInfo.Timestamp
+		}
+	}
+	if orderID == "" {
+		eventTime := ""
+		if head.EventTime != nil {
+			eventTime = *head.EventTime
+		}
+		return head.CorrelationID + "|" + head.EventType + "|" + eventTime, head.EventType, "", 0
+	}
+	return head.CorrelationID + "|" + orderID + "|" + strconv.FormatInt(timestamp, 10), head.EventType, orderID, timestamp
+}
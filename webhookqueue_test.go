@@ -0,0 +1,220 @@
+package goiikoapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testOrderEvent(correlationID, orderID string, timestamp int64) json.RawMessage {
+	return json.RawMessage(fmt.Sprintf(`{"eventType":%q,"correlationId":%q,"organizationId":"org","eventInfo":{"id":%q,"timestamp":%d}}`,
+		WebHookEventDeliveryOrderUpdate, correlationID, orderID, timestamp))
+}
+
+// enqueueReady сохраняет события и сдвигает время получения за пределы ReorderWindow
+func enqueueReady(t *testing.T, q *WebHookQueue, raws ...json.RawMessage) {
+	t.Helper()
+	if err := q.Enqueue(raws); err != nil {
+		t.Fatalf("Enqueue() error = %v", err)
+	}
+	recs, err := q.store.List(WebHookRecordPending)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, rec := range recs {
+		rec.ReceivedAt = rec.ReceivedAt.Add(-time.Hour)
+		if err := q.store.Update(rec); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+// collect consumer, записывающий "orderId@timestamp" доставленных событий
+func collect(delivered *[]string) func(context.Context, WebHookEvent) error {
+	return func(ctx context.Context, ev WebHookEvent) error {
+		switch e := ev.(type) {
+		case *DeliveryOrderUpdateEvent:
+			*delivered = append(*delivered, fmt.Sprintf("%s@%d", e.EventInfo.ID, e.EventInfo.Timestamp))
+		default:
+			*delivered = append(*delivered, ev.Type())
+		}
+		return nil
+	}
+}
+
+func TestWebHookQueueDelivery(t *testing.T) {
+	tests := []struct {
+		name      string
+		batches   [][]json.RawMessage
+		want      []string
+		wantDead  int
+		wantError bool
+	}{
+		{
+			name:    "orders sorted by timestamp",
+			batches: [][]json.RawMessage{{testOrderEvent("c3", "1", 30), testOrderEvent("c1", "1", 10), testOrderEvent("c2", "2", 20)}},
+			want:    []string{"1@10", "1@30", "2@20"},
+		},
+		{
+			name:    "duplicates dropped",
+			batches: [][]json.RawMessage{{testOrderEvent("c1", "1", 10)}, {testOrderEvent("c1", "1", 10)}},
+			want:    []string{"1@10"},
+		},
+		{
+			name:      "late event goes to dead letters",
+			batches:   [][]json.RawMessage{{testOrderEvent("c2", "1", 20)}, {testOrderEvent("c1", "1", 10)}},
+			want:      []string{"1@20"},
+			wantDead:  1,
+			wantError: true,
+		},
+		{
+			name:      "undecodable event goes to dead letters",
+			batches:   [][]json.RawMessage{{json.RawMessage(`{"eventType":"DeliveryOrderUpdate","eventInfo":{"id":"1","timestamp":"bad"}}`), testOrderEvent("c1", "2", 10)}},
+			want:      []string{"2@10"},
+			wantDead:  1,
+			wantError: true,
+		},
+		{
+			name:    "events without order",
+			batches: [][]json.RawMessage{{json.RawMessage(`{"eventType":"StopListUpdate","correlationId":"s1"}`), json.RawMessage(`{"eventType":"StopListUpdate","correlationId":"s2"}`)}},
+			want:    []string{WebHookEventStopListUpdate, WebHookEventStopListUpdate},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var errs []error
+			q := NewWebHookQueue(NewMemoryWebHookStore(), WebHookQueueConfig{OnError: func(err error) { errs = append(errs, err) }})
+			var delivered []string
+			for _, batch := range tt.batches {
+				enqueueReady(t, q, batch...)
+				if err := q.ProcessPending(context.Background(), collect(&delivered)); err != nil {
+					t.Fatalf("ProcessPending() error = %v", err)
+				}
+			}
+			if !reflect.DeepEqual(delivered, tt.want) {
+				t.Errorf("delivered = %v, want %v", delivered, tt.want)
+			}
+			dead, _ := q.DeadLetters()
+			if len(dead) != tt.wantDead {
+				t.Errorf("dead letters = %d, want %d", len(dead), tt.wantDead)
+			}
+			if (len(errs) > 0) != tt.wantError {
+				t.Errorf("OnError calls = %v, want error: %v", errs, tt.wantError)
+			}
+		})
+	}
+}
+
+func TestWebHookQueueRetryAndRequeue(t *testing.T) {
+	q := NewWebHookQueue(NewMemoryWebHookStore(), WebHookQueueConfig{MaxAttempts: 2, RetryDelay: time.Nanosecond})
+	enqueueReady(t, q, testOrderEvent("c2", "1", 20))
+	fail := func(context.Context, WebHookEvent) error { return fmt.Errorf("consumer down") }
+	for i := 0; i < 2; i++ {
+		time.Sleep(time.Millisecond)
+		if err := q.ProcessPending(context.Background(), fail); err != nil {
+			t.Fatal(err)
+		}
+	}
+	dead, _ := q.DeadLetters()
+	if len(dead) != 1 || dead[0].Attempts != 2 {
+		t.Fatalf("dead letters = %+v, want one record after 2 attempts", dead)
+	}
+
+	// более новое событие заказа доставлено, но возвращенное из dead-letter все равно доставляется
+	var delivered []string
+	enqueueReady(t, q, testOrderEvent("c3", "1", 30))
+	if err := q.ProcessPending(context.Background(), collect(&delivered)); err != nil {
+		t.Fatal(err)
+	}
+	if err := q.Requeue(dead[0].Key); err != nil {
+		t.Fatalf("Requeue() error = %v", err)
+	}
+	if err := q.ProcessPending(context.Background(), collect(&delivered)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1@30", "1@20"}; !reflect.DeepEqual(delivered, want) {
+		t.Errorf("delivered = %v, want %v", delivered, want)
+	}
+	if err := q.Requeue("missing"); err != ErrWebHookRecordNotFound {
+		t.Errorf("Requeue(missing) error = %v, want ErrWebHookRecordNotFound", err)
+	}
+}
+
+func TestWebHookQueuePruneKeepsOrderState(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "webhooks.json")
+	store, err := NewFileWebHookStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q := NewWebHookQueue(store, WebHookQueueConfig{})
+	var delivered []string
+	enqueueReady(t, q, testOrderEvent("c1", "1", 10), testOrderEvent("c2", "1", 20))
+	if err := q.ProcessPending(context.Background(), collect(&delivered)); err != nil {
+		t.Fatal(err)
+	}
+	n, err := q.Prune(0)
+	if err != nil || n != 1 {
+		t.Fatalf("Prune() = %d, %v, want 1", n, err)
+	}
+
+	// после перезапуска опоздавшее событие заказа по-прежнему уходит в dead-letter
+	store, err = NewFileWebHookStore(path)
+	if err != nil {
+		t.Fatal(err)
+	}
+	q = NewWebHookQueue(store, WebHookQueueConfig{})
+	enqueueReady(t, q, testOrderEvent("c0", "1", 5))
+	if err := q.ProcessPending(context.Background(), collect(&delivered)); err != nil {
+		t.Fatal(err)
+	}
+	if want := []string{"1@10", "1@20"}; !reflect.DeepEqual(delivered, want) {
+		t.Errorf("delivered = %v, want %v", delivered, want)
+	}
+	if dead, _ := q.DeadLetters(); len(dead) != 1 {
+		t.Errorf("dead letters = %d, want 1", len(dead))
+	}
+}
+
+func TestWebHookQueueConsumerCanUseQueue(t *testing.T) {
+	q := NewWebHookQueue(NewMemoryWebHookStore(), WebHookQueueConfig{})
+	enqueueReady(t, q, testOrderEvent("c1", "1", 10))
+	done := make(chan error, 1)
+	go func() {
+		done <- q.ProcessPending(context.Background(), func(ctx context.Context, ev WebHookEvent) error {
+			if _, err := q.DeadLetters(); err != nil {
+				return err
+			}
+			return q.Enqueue([]json.RawMessage{testOrderEvent("c2", "1", 20)})
+		})
+	}()
+	select {
+	case err := <-done:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(5 * time.Second):
+		t.Fatal("ProcessPending deadlocked on consumer calling back into the queue")
+	}
+}
+
+func TestWebHookRetryDelay(t *testing.T) {
+	tests := []struct {
+		base     time.Duration
+		attempts int
+		want     time.Duration
+	}{
+		{time.Second, 1, time.Second},
+		{time.Second, 3, 4 * time.Second},
+		{time.Second, 1000, maxWebHookRetryDelay},
+		{2 * time.Hour, 1, maxWebHookRetryDelay},
+	}
+	for _, tt := range tests {
+		if got := webHookRetryDelay(tt.base, tt.attempts); got != tt.want {
+			t.Errorf("webHookRetryDelay(%v, %d) = %v, want %v", tt.base, tt.attempts, got, tt.want)
+		}
+	}
+}